@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lpoulter1/fpl-cli/internal/aliases"
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/spf13/cobra"
+)
+
+// aliasListVersion is the schema_version of aliasList JSON output.
+const aliasListVersion = 1
+
+type aliasList struct {
+	SchemaVersion int             `json:"schema_version"`
+	Path          string          `json:"path"`
+	Aliases       []aliases.Alias `json:"aliases"`
+}
+
+func newAliasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage player nicknames",
+		Long: `Manage nicknames that resolve to a specific player, e.g. "KDB" or "Trent".
+
+Aliases are stored in ~/.config/fpl/aliases.yaml (under the OS config directory)
+and are checked before fuzzy matching whenever --name is used. They are keyed on
+the player's code, which stays the same across seasons even though player IDs
+are reassigned every summer.`,
+	}
+	cmd.AddCommand(newAliasAddCmd(), newAliasRmCmd(), newAliasLsCmd())
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newAliasCmd())
+	registerReportSchema(reportSchema{
+		Name:        "alias-list",
+		Version:     aliasListVersion,
+		Description: "Saved player nicknames from fpl alias ls --json",
+		Sample:      aliasList{},
+	})
+}
+
+func newAliasAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <alias> <player name or ID>",
+		Short: "Add or replace a player nickname",
+		Example: `  fpl alias add KDB "De Bruyne"
+  fpl alias add Trent 311
+  fpl alias add Bruno "Fernandes MUN"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAliasAdd(cmd.Context(), cmd, args[0], args[1])
+		},
+	}
+}
+
+func runAliasAdd(ctx context.Context, cmd *cobra.Command, name, player string) error {
+	if fpl.NormalizeName(name) == "" {
+		return fmt.Errorf("alias %q has no letters or digits", name)
+	}
+	store, err := aliases.Open()
+	if err != nil {
+		return err
+	}
+
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+
+	var target *fpl.Element
+	if id, err := strconv.Atoi(strings.TrimSpace(player)); err == nil {
+		target = ix.Player(id)
+		if target == nil {
+			return fmt.Errorf("player with ID %d not found in bootstrap data", id)
+		}
+	} else {
+		query, err := ix.ParseQuery(player)
+		if err != nil {
+			return err
+		}
+		var suggestions []fpl.MatchSuggestion
+		target, suggestions, err = ix.Find(query, nil)
+		if err != nil {
+			return err
+		}
+		if fpl.IsAmbiguous(suggestions) {
+			if !canPrompt(cmd.InOrStdin(), cmd.ErrOrStderr()) {
+				return &fpl.AmbiguousMatchError{Query: player, Suggestions: suggestions}
+			}
+			target, err = promptForPlayer(cmd.InOrStdin(), cmd.ErrOrStderr(), player, suggestions, ix)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	store.Set(aliases.Alias{Name: name, Code: target.Code, Player: playerDisplayName(target)})
+	if err := store.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s → %s (code %d)\n", name, playerDisplayName(target), target.Code)
+	return nil
+}
+
+func newAliasRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm <alias>",
+		Aliases: []string{"remove"},
+		Short:   "Remove a player nickname",
+		Args:    cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			store, err := aliases.Open()
+			if err != nil || len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return aliasCompletions(store, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := aliases.Open()
+			if err != nil {
+				return err
+			}
+			if !store.Remove(args[0]) {
+				return fmt.Errorf("no alias named %q", args[0])
+			}
+			if err := store.Save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newAliasLsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List player nicknames",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := aliases.Open()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if outputFormat() == outputJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(aliasList{
+					SchemaVersion: aliasListVersion,
+					Path:          store.Path(),
+					Aliases:       append([]aliases.Alias{}, store.Aliases...),
+				})
+			}
+			if len(store.Aliases) == 0 {
+				fmt.Fprintf(out, "No aliases defined in %s.\n", store.Path())
+				return nil
+			}
+			tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "Alias\tCode\tPlayer")
+			for _, a := range store.Aliases {
+				fmt.Fprintf(tw, "%s\t%d\t%s\n", a.Name, a.Code, a.Player)
+			}
+			return tw.Flush()
+		},
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/spf13/cobra"
+)
+
+// summaryConcurrency bounds parallel element-summary requests so large
+// batches stay polite to the FPL API.
+const summaryConcurrency = 8
+
+// playerBatchVersion is the schema_version of playerBatchReport JSON output.
+const playerBatchVersion = 1
+
+// playerRef is one requested player, either by ID or by name query.
+type playerRef struct {
+	ID   int
+	Name string
+}
+
+func (r playerRef) String() string {
+	if r.ID > 0 {
+		return fmt.Sprintf("ID %d", r.ID)
+	}
+	return strconv.Quote(r.Name)
+}
+
+type playerBatchReport struct {
+	SchemaVersion int            `json:"schema_version"`
+	Players       []playerReport `json:"players"`
+	Errors        []batchError   `json:"errors"`
+}
+
+type batchError struct {
+	Query string `json:"query"`
+	Error string `json:"error"`
+}
+
+func init() {
+	registerReportSchema(reportSchema{
+		Name:        "player-batch",
+		Version:     playerBatchVersion,
+		Description: "Reports for several players plus per-query errors from fpl player --json with multiple players",
+		Sample:      playerBatchReport{},
+	})
+}
+
+// collectPlayerRefs gathers --id, --name and --from-file entries in that order.
+func collectPlayerRefs(cmd *cobra.Command, opts *playerOptions) ([]playerRef, error) {
+	refs := make([]playerRef, 0, len(opts.ids)+len(opts.names))
+	for _, id := range opts.ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("player ID must be positive: %d", id)
+		}
+		refs = append(refs, playerRef{ID: id})
+	}
+	for _, name := range opts.names {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("--name cannot be empty")
+		}
+		refs = append(refs, playerRef{Name: name})
+	}
+
+	if opts.fromFile == "" {
+		return refs, nil
+	}
+	var in io.Reader
+	if opts.fromFile == "-" {
+		in = cmd.InOrStdin()
+	} else {
+		f, err := os.Open(opts.fromFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	fileRefs, err := parsePlayerRefs(in)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", opts.fromFile, err)
+	}
+	return append(refs, fileRefs...), nil
+}
+
+// parsePlayerRefs reads one player per line. Lines that are entirely digits
+// are IDs; blank lines and lines starting with # are skipped.
+func parsePlayerRefs(in io.Reader) ([]playerRef, error) {
+	var refs []playerRef
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		refs = append(refs, parsePlayerRef(line))
+	}
+	return refs, scanner.Err()
+}
+
+// parsePlayerRef treats an all-digit value as an ID and anything else as a
+// name query.
+func parsePlayerRef(value string) playerRef {
+	value = strings.TrimSpace(value)
+	if id, err := strconv.Atoi(value); err == nil && id > 0 {
+		return playerRef{ID: id}
+	}
+	return playerRef{Name: value}
+}
+
+type batchResult struct {
+	ref         playerRef
+	target      *fpl.Element
+	suggestions []fpl.MatchSuggestion
+	summary     *fpl.PlayerSummary
+	err         error
+}
+
+func runPlayerBatch(ctx context.Context, cmd *cobra.Command, opts *playerOptions, client *fpl.Client, resolver *playerResolver, refs []playerRef, columns []historyColumn, sortKeys []sortKey) error {
+	ix := resolver.ix
+	results := make([]batchResult, 0, len(refs))
+	seen := make(map[int]struct{}, len(refs))
+	for _, ref := range refs {
+		target, suggestions, err := resolver.resolve(ref)
+		if err == nil {
+			if _, dup := seen[target.ID]; dup {
+				continue
+			}
+			seen[target.ID] = struct{}{}
+		}
+		results = append(results, batchResult{ref: ref, target: target, suggestions: suggestions, err: err})
+	}
+
+	fetchSummaries(ctx, client, results)
+
+	batch := playerBatchReport{SchemaVersion: playerBatchVersion, Players: []playerReport{}, Errors: []batchError{}}
+	for _, res := range results {
+		if res.err != nil {
+			batch.Errors = append(batch.Errors, batchError{Query: res.ref.String(), Error: res.err.Error()})
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s: %v\n", res.ref, res.err)
+			continue
+		}
+		report := buildPlayerReport(res.target, ix, res.summary, &opts.gws)
+		if len(res.suggestions) > 0 {
+			report.Match = buildMatchInfo(res.ref.Name, res.suggestions, ix)
+		}
+		sortRows(report.Gameweeks, sortKeys)
+		batch.Players = append(batch.Players, report)
+	}
+
+	out := cmd.OutOrStdout()
+	var err error
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(batch)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, report := range batch.Players {
+			if err = enc.Encode(report); err != nil {
+				break
+			}
+		}
+	case outputCSV:
+		err = writeCSV(out, batchCSVColumns(columns), batchCSVRows(batch.Players))
+	default:
+		printBatchTable(out, batch.Players)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(batch.Errors) > 0 {
+		return fmt.Errorf("%d of %d players could not be loaded", len(batch.Errors), len(results))
+	}
+	return nil
+}
+
+// fetchSummaries loads element summaries for every resolved result using a
+// bounded pool of workers, recording failures on the result.
+func fetchSummaries(ctx context.Context, client *fpl.Client, results []batchResult) {
+	sem := make(chan struct{}, summaryConcurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		if results[i].err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(res *batchResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			res.summary, res.err = client.PlayerSummary(ctx, res.target.ID)
+		}(&results[i])
+	}
+	wg.Wait()
+}
+
+var batchTableColumns = []tableColumn[playerReport]{
+	{Header: "Player", Priority: 0, Value: func(r playerReport) string { return r.Player.Name }},
+	{Header: "Team", Priority: 3, Value: func(r playerReport) string { return r.Player.Team }},
+	{Header: "Pos", Priority: 2, Value: func(r playerReport) string { return r.Player.Position }},
+	floatColumn("cost", "£", 2, func(r playerReport) float64 { return r.Player.Cost }),
+	intColumn("matches", "GWs", 4, func(r playerReport) int { return r.Totals.Matches }),
+	intColumn("minutes", "Min", 3, func(r playerReport) int { return r.Totals.Minutes }),
+	intColumn("goals", "G", 1, func(r playerReport) int { return r.Totals.Goals }),
+	intColumn("assists", "A", 1, func(r playerReport) int { return r.Totals.Assists }),
+	intColumn("clean_sheets", "CS", 3, func(r playerReport) int { return r.Totals.CleanSheets }),
+	floatColumn("xgi_per_90", "xGI/90", 2, func(r playerReport) float64 { return r.Totals.Derived.XGIPer90 }),
+	floatColumn("points_per_90", "Pts/90", 2, func(r playerReport) float64 { return r.Totals.Derived.PointsPer90 }),
+	floatColumn("rolling_avg_5", "Avg5", 1, func(r playerReport) float64 { return r.Totals.Derived.RollingAvg5 }),
+	intColumn("points", "Pts", 0, func(r playerReport) int { return r.Totals.Points }),
+}
+
+// printBatchTable prints one totals row per player.
+func printBatchTable(out io.Writer, reports []playerReport) {
+	colors := newPalette(out, rootOpts.color)
+	if len(reports) == 0 {
+		fmt.Fprintln(out, "No players loaded.")
+		return
+	}
+	visible := fitColumns(batchTableColumns, reports, terminalWidth(out))
+	renderTable(out, visible, reports, colors)
+}
+
+// batchRow is a gameweek row tagged with its player for combined CSV output.
+type batchRow struct {
+	PlayerID int
+	Player   string
+	Row      historyRow
+}
+
+func batchCSVColumns(columns []historyColumn) []tableColumn[batchRow] {
+	out := []tableColumn[batchRow]{
+		intColumn("player_id", "ID", 0, func(r batchRow) int { return r.PlayerID }),
+		{Key: "player", Header: "Player", Value: func(r batchRow) string { return r.Player }},
+	}
+	for _, col := range columns {
+		value := col.Value
+		out = append(out, tableColumn[batchRow]{
+			Key:    col.Key,
+			Header: col.Header,
+			Value:  func(r batchRow) string { return value(r.Row) },
+		})
+	}
+	return out
+}
+
+func batchCSVRows(reports []playerReport) []batchRow {
+	var rows []batchRow
+	for _, report := range reports {
+		for _, row := range report.Gameweeks {
+			rows = append(rows, batchRow{PlayerID: report.Player.ID, Player: report.Player.Name, Row: row})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParsePlayerRefs(t *testing.T) {
+	input := `# weekly shortlist
+Salah
+  123
+
+Gordon NEW
+0
+`
+	refs, err := parsePlayerRefs(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []playerRef{{Name: "Salah"}, {ID: 123}, {Name: "Gordon NEW"}, {Name: "0"}}
+	if len(refs) != len(want) {
+		t.Fatalf("expected %d refs, got %+v", len(want), refs)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Fatalf("ref %d: got %+v want %+v", i, refs[i], want[i])
+		}
+	}
+}
+
+func TestBatchCSVPrefixesPlayer(t *testing.T) {
+	reports := []playerReport{
+		{Player: playerSummaryInfo{ID: 1, Name: "Mohamed Salah"}, Gameweeks: []historyRow{{Round: 1, Points: 8}}},
+		{Player: playerSummaryInfo{ID: 2, Name: "Erling Haaland"}, Gameweeks: []historyRow{{Round: 1, Points: 13}, {Round: 2, Points: 2}}},
+	}
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, batchCSVColumns(mustSelectColumns([]string{"gw", "pts"})), batchCSVRows(reports)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "player_id,player,round,points\n1,Mohamed Salah,1,8\n2,Erling Haaland,1,13\n2,Erling Haaland,2,2\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,442 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/projection"
+	"github.com/spf13/cobra"
+)
+
+// captainReportVersion is the schema_version of captainReport JSON output.
+const captainReportVersion = 1
+
+// captainOptionVersion is the schema_version of each fpl captain NDJSON line.
+const captainOptionVersion = 1
+
+const (
+	// captainPool is how many of the best-projected players are considered
+	// when ranking without a squad.
+	captainPool = 30
+	// captainSkew concentrates the estimated captaincy on the best-projected
+	// players: each player's share grows with ownership × xPts^captainSkew.
+	captainSkew = 3
+	// shieldEO and differentialOwnership mark the ends of the risk view.
+	shieldEO              = 1.0
+	differentialOwnership = 0.1
+)
+
+type captainOptions struct {
+	entry      int
+	all        bool
+	formWeight float64
+	limit      int
+}
+
+type captainReport struct {
+	SchemaVersion int             `json:"schema_version"`
+	Gameweek      int             `json:"gameweek"`
+	Entry         int             `json:"entry,omitempty"`
+	EntryName     string          `json:"entry_name,omitempty"`
+	FormWeight    float64         `json:"form_weight"`
+	Captain       int             `json:"captain"`
+	ViceCaptain   int             `json:"vice_captain"`
+	Options       []captainOption `json:"options"`
+}
+
+type captainOption struct {
+	Rank     int              `json:"rank"`
+	ID       int              `json:"id"`
+	Name     string           `json:"name"`
+	Team     string           `json:"team"`
+	Position string           `json:"position"`
+	Fixtures []captainFixture `json:"fixtures"`
+	// Role is the player's place in the entry's current picks: captain,
+	// vice, starter or bench. It is empty when ranking all players.
+	Role string `json:"role,omitempty"`
+	// Form is the player's average over their last five matches; Model is
+	// the fpl predict projection. XPts blends the two.
+	Form  float64 `json:"form"`
+	Model float64 `json:"model"`
+	XPts  float64 `json:"xpts"`
+	// Ownership and EO are fractions of managers: 0.5 is 50%. EO is
+	// estimated as ownership plus an estimated captaincy share.
+	Ownership float64 `json:"ownership"`
+	EO        float64 `json:"eo"`
+	// VsField is the expected points gained on an average manager by
+	// captaining the player: (2 - EO) × xPts.
+	VsField float64 `json:"vs_field"`
+	Risk    string  `json:"risk,omitempty"`
+}
+
+// captainOptionLine is one ranked option as streamed with --output ndjson.
+type captainOptionLine struct {
+	SchemaVersion int `json:"schema_version"`
+	captainOption
+}
+
+type captainFixture struct {
+	Opponent   string `json:"opponent"`
+	Home       bool   `json:"home"`
+	Difficulty int    `json:"difficulty"`
+}
+
+func newCaptainCmd() *cobra.Command {
+	opts := &captainOptions{}
+	cmd := &cobra.Command{
+		Use:   "captain",
+		Short: "Rank captain options for the next gameweek",
+		Long: `Rank captain options for the next gameweek from a manager's squad, or from
+every player when no entry is given or with --all.
+
+Expected points (xPts) blend the fpl predict model, which already weighs each
+fixture's venue and opponent strength, with recent form: the player's average
+over their last five matches, scaled for fixture difficulty (10% per step)
+and venue. Double gameweeks add both fixtures; blanks score nothing.
+
+The risk view compares each pick with the field. Effective ownership (EO) is
+estimated as ownership plus a captaincy share that assumes managers favour
+the best-projected players they own. "vs field" is the expected points gained
+on an average manager by captaining the player, (2 - EO) × xPts. Shields are
+owned and captained so widely (EO of 100% or more) that not captaining them
+risks rank; differentials are owned by under 10% of managers.`,
+		Example: `  fpl captain --entry 123456
+  fpl captain --all --limit 5
+  fpl captain --form-weight 0 --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCaptain(cmd.Context(), cmd, opts)
+		},
+	}
+	addEntryFlag(cmd, &opts.entry)
+	cmd.Flags().BoolVar(&opts.all, "all", false, "rank all players even when an entry is configured")
+	cmd.Flags().Float64Var(&opts.formWeight, "form-weight", 0.3, "weight of recent form against the projection model, from 0 to 1")
+	cmd.Flags().IntVar(&opts.limit, "limit", 10, "number of options to list (0 for all)")
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newCaptainCmd())
+	registerReportSchema(reportSchema{
+		Name:        "captain",
+		Version:     captainReportVersion,
+		Description: "Ranked captain options from fpl captain --json",
+		Sample:      captainReport{},
+	})
+	registerReportSchema(reportSchema{
+		Name:        "captain-option",
+		Version:     captainOptionVersion,
+		Description: "One ranked captain option per line from fpl captain --output ndjson",
+		Sample:      captainOptionLine{},
+	})
+}
+
+func runCaptain(ctx context.Context, cmd *cobra.Command, opts *captainOptions) error {
+	if opts.formWeight < 0 || opts.formWeight > 1 {
+		return fmt.Errorf("--form-weight must be between 0 and 1")
+	}
+	if opts.limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+	if opts.all && cmd.Flags().Changed("entry") {
+		return fmt.Errorf("--all and --entry cannot be combined")
+	}
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+	h, err := loadHorizon(ctx, client, ix, 1)
+	if err != nil {
+		return err
+	}
+	gw := h.Next
+	model := projection.NewModel(bootstrap, h.Schedule, gw)
+	modelPts := make(map[int]float64, len(bootstrap.Elements))
+	for _, el := range bootstrap.Elements {
+		modelPts[el.ID] = model.Project(el.ID, gw).Points
+	}
+
+	report := captainReport{SchemaVersion: captainReportVersion, Gameweek: gw, FormWeight: opts.formWeight}
+	entryID := 0
+	if !opts.all {
+		id, err := resolveEntry(cmd, opts.entry)
+		if err != nil && cmd.Flags().Changed("entry") {
+			return err
+		}
+		entryID = id
+	}
+	roles := make(map[int]string)
+	var candidates []int
+	if entryID > 0 {
+		mp, err := loadManagerPicks(ctx, client, entryID)
+		if err != nil {
+			return err
+		}
+		report.Entry, report.EntryName = entryID, mp.Entry.Name
+		for _, p := range mp.Picks.Picks {
+			candidates = append(candidates, p.Element)
+			roles[p.Element] = pickRole(p)
+		}
+	} else {
+		candidates = topProjected(bootstrap.Elements, modelPts, captainPool)
+	}
+
+	summaries, err := fetchPlayerSummaries(ctx, client, candidates)
+	if err != nil {
+		return err
+	}
+	histories := make(map[int][]fpl.HistoryEntry, len(summaries))
+	for id, s := range summaries {
+		histories[id] = s.History
+	}
+	form := projection.NewForm(bootstrap, h.Schedule, gw, histories)
+	blend := projection.Blend{{Projection: model, Weight: 1 - opts.formWeight}, {Projection: form, Weight: opts.formWeight}}
+	eo := estimateEO(bootstrap.Elements, modelPts)
+
+	for _, id := range candidates {
+		el := ix.Player(id)
+		if el == nil {
+			return fmt.Errorf("player %d is missing from bootstrap data", id)
+		}
+		xpts := blend.Project(id, gw).Points
+		own := parseStat(el.SelectedBy) / 100
+		opt := captainOption{
+			ID:        id,
+			Name:      el.WebName,
+			Team:      teamShortName(ix, el.Team),
+			Fixtures:  []captainFixture{},
+			Role:      roles[id],
+			Form:      roundTo(projection.RecentAverage(histories[id], projection.FormWindow), 2),
+			Model:     roundTo(modelPts[id], 2),
+			XPts:      roundTo(xpts, 2),
+			Ownership: roundTo(own, 4),
+			EO:        roundTo(eo[id], 4),
+			VsField:   roundTo((2-eo[id])*xpts, 2),
+			Risk:      captainRisk(own, eo[id]),
+		}
+		if pos := ix.Position(el.ElementType); pos != nil {
+			opt.Position = pos.SingularNameShort
+		}
+		for _, m := range h.Schedule.Matches(el.Team, gw) {
+			opt.Fixtures = append(opt.Fixtures, captainFixture{
+				Opponent:   teamShortName(ix, m.Opponent),
+				Home:       m.Home,
+				Difficulty: m.Difficulty,
+			})
+		}
+		report.Options = append(report.Options, opt)
+	}
+	sort.SliceStable(report.Options, func(i, j int) bool {
+		return report.Options[i].XPts > report.Options[j].XPts
+	})
+	for i := range report.Options {
+		report.Options[i].Rank = i + 1
+	}
+	if len(report.Options) > 0 {
+		report.Captain = report.Options[0].ID
+	}
+	if len(report.Options) > 1 {
+		report.ViceCaptain = report.Options[1].ID
+	}
+	if opts.limit > 0 && len(report.Options) > opts.limit {
+		report.Options = report.Options[:opts.limit]
+	}
+
+	out := cmd.OutOrStdout()
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, o := range report.Options {
+			if err := enc.Encode(captainOptionLine{captainOptionVersion, o}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return writeCSV(out, captainColumns, report.Options)
+	}
+	printCaptainTable(out, report)
+	return nil
+}
+
+func pickRole(p fpl.Pick) string {
+	switch {
+	case p.IsCaptain:
+		return "captain"
+	case p.IsViceCaptain:
+		return "vice"
+	case p.Position > 11:
+		return "bench"
+	}
+	return "starter"
+}
+
+// topProjected returns the IDs of the n available players with the most
+// projected points.
+func topProjected(elements []fpl.Element, points map[int]float64, n int) []int {
+	var ids []int
+	for _, el := range elements {
+		if el.Status != "u" && el.Status != "n" && points[el.ID] > 0 {
+			ids = append(ids, el.ID)
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool { return points[ids[i]] > points[ids[j]] })
+	if len(ids) > n {
+		ids = ids[:n]
+	}
+	return ids
+}
+
+// estimateEO estimates each player's effective ownership for the gameweek:
+// their ownership plus the share of managers captaining them. FPL does not
+// publish captaincy before the deadline, so every manager is assumed to
+// captain someone, split in proportion to ownership × xPts^captainSkew. Nobody
+// can be captained by more managers than own them; any excess is shared out
+// among the rest.
+func estimateEO(elements []fpl.Element, points map[int]float64) map[int]float64 {
+	own := make(map[int]float64, len(elements))
+	weights := make(map[int]float64, len(elements))
+	for _, el := range elements {
+		own[el.ID] = parseStat(el.SelectedBy) / 100
+		if p := points[el.ID]; p > 0 {
+			weights[el.ID] = own[el.ID] * math.Pow(p, captainSkew)
+		}
+	}
+	captained := make(map[int]float64, len(weights))
+	remaining := 1.0
+	for remaining > 1e-9 {
+		total := 0.0
+		for _, w := range weights {
+			total += w
+		}
+		if total == 0 {
+			break
+		}
+		var capped []int
+		for id, w := range weights {
+			if remaining*w/total >= own[id] {
+				capped = append(capped, id)
+			}
+		}
+		if len(capped) == 0 {
+			for id, w := range weights {
+				captained[id] = remaining * w / total
+			}
+			break
+		}
+		for _, id := range capped {
+			captained[id] = own[id]
+			remaining -= own[id]
+			delete(weights, id)
+		}
+	}
+	eo := make(map[int]float64, len(elements))
+	for id, o := range own {
+		eo[id] = o + captained[id]
+	}
+	return eo
+}
+
+func captainRisk(ownership, eo float64) string {
+	switch {
+	case eo >= shieldEO:
+		return "shield"
+	case ownership < differentialOwnership:
+		return "differential"
+	}
+	return ""
+}
+
+func fixtureLabels(fixtures []captainFixture) string {
+	if len(fixtures) == 0 {
+		return "blank"
+	}
+	labels := make([]string, len(fixtures))
+	for i, f := range fixtures {
+		labels[i] = matchLabel(f.Opponent, f.Home)
+	}
+	return strings.Join(labels, " + ")
+}
+
+func fixtureDifficulty(fixtures []captainFixture) string {
+	if len(fixtures) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(fixtures))
+	for i, f := range fixtures {
+		parts[i] = strconv.Itoa(f.Difficulty)
+	}
+	return strings.Join(parts, "+")
+}
+
+func percent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v*100)
+}
+
+var captainColumns = []tableColumn[captainOption]{
+	intColumn("rank", "#", 0, func(o captainOption) int { return o.Rank }),
+	{Key: "player", Header: "Player", Priority: 0, Value: func(o captainOption) string { return o.Name }},
+	{Key: "team", Header: "Team", Priority: 2, Value: func(o captainOption) string { return o.Team }},
+	{Key: "position", Header: "Pos", Priority: 3, Value: func(o captainOption) string { return o.Position }},
+	{Key: "role", Header: "Role", Priority: 2, Value: func(o captainOption) string { return o.Role }},
+	{Key: "fixtures", Header: "Fixtures", Priority: 1, Value: func(o captainOption) string { return fixtureLabels(o.Fixtures) }},
+	{Key: "difficulty", Header: "FDR", Priority: 3, AlignRight: true, Value: func(o captainOption) string { return fixtureDifficulty(o.Fixtures) }},
+	floatColumn("form", "Form", 3, func(o captainOption) float64 { return o.Form }),
+	floatColumn("model", "Model", 4, func(o captainOption) float64 { return o.Model }),
+	floatColumn("xpts", "xPts", 0, func(o captainOption) float64 { return o.XPts }),
+	{Key: "ownership", Header: "Own", Priority: 2, AlignRight: true, Value: func(o captainOption) string { return percent(o.Ownership) }},
+	{Key: "eo", Header: "EO", Priority: 1, AlignRight: true, Value: func(o captainOption) string { return percent(o.EO) }},
+	floatColumn("vs_field", "vs Field", 1, func(o captainOption) float64 { return o.VsField }),
+	{Key: "risk", Header: "Risk", Priority: 2, Value: func(o captainOption) string { return o.Risk }},
+}
+
+func printCaptainTable(out io.Writer, report captainReport) {
+	colors := newPalette(out, rootOpts.color)
+	title := fmt.Sprintf("%s | GW %d", colors.bold("Captain picks"), report.Gameweek)
+	if report.EntryName != "" {
+		title += " | " + report.EntryName
+	}
+	fmt.Fprintln(out, title)
+	fmt.Fprintln(out)
+	if len(report.Options) == 0 {
+		fmt.Fprintln(out, "No captain options: no available player projects any points this gameweek.")
+		return
+	}
+	columns := captainColumns
+	if report.Entry == 0 {
+		columns = withoutColumn(columns, "role")
+	}
+	visible := fitColumns(columns, report.Options, terminalWidth(out))
+	renderTable(out, visible, report.Options, colors)
+
+	best := report.Options[0]
+	fmt.Fprintf(out, "\nCaptain %s (%.2f xPts, %s EO)", best.Name, best.XPts, percent(best.EO))
+	if len(report.Options) > 1 {
+		fmt.Fprintf(out, ", vice %s", report.Options[1].Name)
+	}
+	fmt.Fprintln(out, ".")
+	fmt.Fprintln(out, colors.dim("EO is estimated from ownership; vs Field = (2 - EO) × xPts."))
+}
+
+func withoutColumn[R any](columns []tableColumn[R], key string) []tableColumn[R] {
+	out := make([]tableColumn[R], 0, len(columns))
+	for _, c := range columns {
+		if c.Key != key {
+			out = append(out, c)
+		}
+	}
+	return out
+}
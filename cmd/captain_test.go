@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestEstimateEO(t *testing.T) {
+	elements := []fpl.Element{
+		{ID: 1, SelectedBy: "50.0"},
+		{ID: 2, SelectedBy: "45.0"},
+		{ID: 3, SelectedBy: "10.0"},
+		{ID: 4, SelectedBy: "90.0"},
+	}
+	// Player 4 is widely owned but blanks, so nobody captains them.
+	eo := estimateEO(elements, map[int]float64{1: 8, 2: 8, 3: 4})
+
+	captained := 0.0
+	for id, own := range map[int]float64{1: 0.5, 2: 0.45, 3: 0.1, 4: 0.9} {
+		captained += eo[id] - own
+	}
+	if math.Abs(captained-1) > 1e-9 {
+		t.Fatalf("expected every manager to captain someone, got %.3f", captained)
+	}
+	if eo[4] != 0.9 {
+		t.Fatalf("expected no captaincy for a blank, got EO %.3f", eo[4])
+	}
+	// Players 1 and 2 would take more armbands than they have owners, so
+	// player 3 picks up the rest.
+	if math.Abs(eo[1]-1) > 1e-9 || math.Abs(eo[2]-0.9) > 1e-9 || math.Abs(eo[3]-0.15) > 1e-9 {
+		t.Fatalf("expected captaincy capped at ownership, got %v", eo)
+	}
+	if got := captainRisk(0.5, eo[1]); got != "shield" {
+		t.Fatalf("expected a shield, got %q", got)
+	}
+	if got := captainRisk(0.05, 0.07); got != "differential" {
+		t.Fatalf("expected a differential, got %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+const rollingWindow = 3
+
+type trendSeries struct {
+	Label  string
+	Values []float64
+	Format string
+}
+
+// printTrendChart draws one sparkline per tracked stat, one tick per fixture in
+// gameweek order regardless of --sort, followed by a rolling average of points.
+func printTrendChart(out io.Writer, rows []historyRow, p palette) {
+	rows = append([]historyRow(nil), rows...)
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Round < rows[j].Round })
+
+	points := make([]float64, len(rows))
+	minutes := make([]float64, len(rows))
+	xgi := make([]float64, len(rows))
+	for i, row := range rows {
+		points[i] = float64(row.Points)
+		minutes[i] = float64(row.Minutes)
+		xgi[i] = row.XGI
+	}
+
+	series := []trendSeries{
+		{Label: "Pts", Values: points, Format: "%.0f"},
+		{Label: "Min", Values: minutes, Format: "%.0f"},
+		{Label: "xGI", Values: xgi, Format: "%.2f"},
+		{Label: fmt.Sprintf("Pts avg%d", rollingWindow), Values: rollingAverage(points, rollingWindow), Format: "%.1f"},
+	}
+
+	labelWidth := 0
+	for _, s := range series {
+		labelWidth = max(labelWidth, len(s.Label))
+	}
+
+	fmt.Fprintf(out, "Trends (GW %d → %d)\n", rows[0].Round, rows[len(rows)-1].Round)
+	for _, s := range series {
+		lo, hi := bounds(s.Values)
+		fmt.Fprintf(out, "%s  %s  %s\n",
+			pad(s.Label, labelWidth, false),
+			p.green(sparkline(s.Values)),
+			p.dim(fmt.Sprintf("min "+s.Format+" | max "+s.Format+" | last "+s.Format, lo, hi, s.Values[len(s.Values)-1])),
+		)
+	}
+}
+
+// sparkline renders values as a row of block characters scaled between the
+// smaller of zero and the series minimum, and the series maximum.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := bounds(values)
+	lo = math.Min(lo, 0)
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkTicks)-1)))
+		}
+		idx = min(max(idx, 0), len(sparkTicks)-1)
+		b.WriteRune(sparkTicks[idx])
+	}
+	return b.String()
+}
+
+// rollingAverage returns the trailing mean over up to window values ending at
+// each position, so early entries average whatever history is available.
+func rollingAverage(values []float64, window int) []float64 {
+	if window <= 0 {
+		return nil
+	}
+	averages := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		averages[i] = sum / float64(min(i+1, window))
+	}
+	return averages
+}
+
+func bounds(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return lo, hi
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]float64{0, 4, 8}); got != "▁▅█" {
+		t.Fatalf("unexpected sparkline %q", got)
+	}
+	if got := sparkline([]float64{0, 0}); got != "▁▁" {
+		t.Fatalf("expected all-zero series to render lowest tick, got %q", got)
+	}
+	if got := sparkline(nil); got != "" {
+		t.Fatalf("expected empty sparkline, got %q", got)
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	got := rollingAverage([]float64{3, 6, 9, 12}, 3)
+	want := []float64{3, 4.5, 6, 9}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("rolling average mismatch at %d: got %v want %v", i, got, want)
+		}
+	}
+}
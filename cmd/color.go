@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiRed       = "\x1b[31m"
+	ansiGreen     = "\x1b[32m"
+	ansiYellow    = "\x1b[33m"
+	ansiBoldGreen = "\x1b[1;32m"
+)
+
+// palette applies ANSI styles when color output is enabled and is a no-op otherwise.
+type palette struct {
+	enabled bool
+}
+
+func newPalette(out io.Writer, mode string) palette {
+	switch mode {
+	case colorAlways:
+		return palette{enabled: true}
+	case colorNever:
+		return palette{}
+	}
+	if _, set := os.LookupEnv("NO_COLOR"); set {
+		return palette{}
+	}
+	return palette{enabled: isTerminal(out)}
+}
+
+func (p palette) wrap(code, text string) string {
+	if !p.enabled || code == "" || text == "" {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+func (p palette) bold(text string) string   { return p.wrap(ansiBold, text) }
+func (p palette) dim(text string) string    { return p.wrap(ansiDim, text) }
+func (p palette) red(text string) string    { return p.wrap(ansiRed, text) }
+func (p palette) green(text string) string  { return p.wrap(ansiGreen, text) }
+func (p palette) yellow(text string) string { return p.wrap(ansiYellow, text) }
+
+// points colors a gameweek points value on a blank-to-haul heat scale.
+func (p palette) points(pts int, text string) string {
+	switch {
+	case pts >= 10:
+		return p.wrap(ansiBoldGreen, text)
+	case pts >= 6:
+		return p.wrap(ansiGreen, text)
+	case pts >= 3:
+		return text
+	case pts >= 0:
+		return p.wrap(ansiDim, text)
+	default:
+		return p.wrap(ansiRed, text)
+	}
+}
+
+// news colors injury and suspension news red and return-to-fitness news green.
+func (p palette) news(status, text string) string {
+	switch status {
+	case "a":
+		return p.wrap(ansiGreen, text)
+	case "d":
+		return p.wrap(ansiYellow, text)
+	case "":
+		return text
+	default:
+		return p.wrap(ansiRed, text)
+	}
+}
+
+func validateColorMode(mode string) error {
+	switch mode {
+	case colorAuto, colorAlways, colorNever:
+		return nil
+	}
+	return fmt.Errorf("invalid --color value %q: expected auto, always or never", mode)
+}
+
+// isTerminal reports whether stream (a reader or writer) is an interactive terminal.
+func isTerminal(stream any) bool {
+	f, ok := stream.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// terminalWidth reports the usable width of out, or 0 when it is unbounded
+// (e.g. output is piped to a file).
+func terminalWidth(out io.Writer) int {
+	if cols, err := strconv.Atoi(strings.TrimSpace(os.Getenv("COLUMNS"))); err == nil && cols > 0 {
+		return cols
+	}
+	f, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
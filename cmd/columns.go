@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// historyColumn is a column of the per-gameweek player table.
+type historyColumn = tableColumn[historyRow]
+
+// columnRegistry lists every gameweek column in its canonical order. Table
+// and CSV output derive their columns from it. JSON output is out of its
+// scope: rows are encoded from historyRow, whose field names match these
+// keys, and always carry every column.
+var columnRegistry = []historyColumn{
+	intColumn("round", "GW", 0, func(r historyRow) int { return r.Round }, "gw"),
+	{
+		Key:      "opponent",
+		Aliases:  []string{"opp"},
+		Header:   "Opponent",
+		Priority: 1,
+		Value:    func(r historyRow) string { return r.Opponent },
+	},
+	{
+		Key:      "home",
+		Aliases:  []string{"venue"},
+		Header:   "H/A",
+		Priority: 5,
+		Value: func(r historyRow) string {
+			if r.Home {
+				return "H"
+			}
+			return "A"
+		},
+	},
+	intColumn("minutes", "Min", 2, func(r historyRow) int { return r.Minutes }, "min", "mins"),
+	intColumn("starts", "St", 5, func(r historyRow) int { return r.Starts }, "start"),
+	intColumn("goals", "G", 3, func(r historyRow) int { return r.Goals }, "g"),
+	intColumn("assists", "A", 3, func(r historyRow) int { return r.Assists }, "a"),
+	intColumn("clean_sheets", "CS", 4, func(r historyRow) int { return r.CleanSheets }, "cs"),
+	floatColumn("xg", "xG", 4, func(r historyRow) float64 { return r.XG }),
+	floatColumn("xa", "xA", 4, func(r historyRow) float64 { return r.XA }),
+	floatColumn("xgi", "xGI", 4, func(r historyRow) float64 { return r.XGI }),
+	{
+		Key:        "points",
+		Aliases:    []string{"pts"},
+		Header:     "Pts",
+		Priority:   0,
+		AlignRight: true,
+		Value:      func(r historyRow) string { return strconv.Itoa(r.Points) },
+		Number:     func(r historyRow) float64 { return float64(r.Points) },
+		Style:      func(p palette, r historyRow, cell string) string { return p.points(r.Points, cell) },
+	},
+}
+
+var defaultColumnKeys = []string{"round", "opponent", "minutes", "goals", "assists", "clean_sheets", "points"}
+
+// historyColumns is the default table layout.
+var historyColumns = mustSelectColumns(defaultColumnKeys)
+
+func intColumn[R any](key, header string, priority int, get func(R) int, aliases ...string) tableColumn[R] {
+	return tableColumn[R]{
+		Key:        key,
+		Aliases:    aliases,
+		Header:     header,
+		Priority:   priority,
+		AlignRight: true,
+		Value:      func(r R) string { return strconv.Itoa(get(r)) },
+		Number:     func(r R) float64 { return float64(get(r)) },
+	}
+}
+
+func floatColumn[R any](key, header string, priority int, get func(R) float64, aliases ...string) tableColumn[R] {
+	return tableColumn[R]{
+		Key:        key,
+		Aliases:    aliases,
+		Header:     header,
+		Priority:   priority,
+		AlignRight: true,
+		Value:      func(r R) string { return strconv.FormatFloat(get(r), 'f', 2, 64) },
+		Number:     get,
+	}
+}
+
+func lookupColumn(name string) (historyColumn, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, col := range columnRegistry {
+		if col.Key == name || strings.EqualFold(col.Header, name) {
+			return col, true
+		}
+		for _, alias := range col.Aliases {
+			if alias == name {
+				return col, true
+			}
+		}
+	}
+	return historyColumn{}, false
+}
+
+func columnKeys() []string {
+	keys := make([]string, len(columnRegistry))
+	for i, col := range columnRegistry {
+		keys[i] = col.Key
+	}
+	return keys
+}
+
+// selectColumns resolves user-supplied column names in the order given,
+// ignoring duplicates.
+func selectColumns(names []string) ([]historyColumn, error) {
+	selected := make([]historyColumn, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		col, ok := lookupColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(columnKeys(), ", "))
+		}
+		if _, dup := seen[col.Key]; dup {
+			continue
+		}
+		seen[col.Key] = struct{}{}
+		selected = append(selected, col)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("at least one column is required (available: %s)", strings.Join(columnKeys(), ", "))
+	}
+	return selected, nil
+}
+
+func mustSelectColumns(names []string) []historyColumn {
+	cols, err := selectColumns(names)
+	if err != nil {
+		panic(err)
+	}
+	return cols
+}
+
+type sortKey struct {
+	Column     historyColumn
+	Descending bool
+}
+
+// parseSortKeys parses a comma-separated list of column[:asc|desc] terms.
+func parseSortKeys(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, term := range strings.Split(spec, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		name, dir, _ := strings.Cut(term, ":")
+		col, ok := lookupColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown sort column %q (available: %s)", name, strings.Join(columnKeys(), ", "))
+		}
+		key := sortKey{Column: col}
+		switch strings.ToLower(strings.TrimSpace(dir)) {
+		case "", "asc":
+		case "desc":
+			key.Descending = true
+		default:
+			return nil, fmt.Errorf("invalid sort direction %q in %q: expected asc or desc", dir, term)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortRows orders rows by keys in priority order, keeping gameweek order for ties.
+func sortRows(rows []historyRow, keys []sortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, key := range keys {
+			cmp := compareColumn(key.Column, rows[i], rows[j])
+			if cmp == 0 {
+				continue
+			}
+			if key.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+func compareColumn[R any](col tableColumn[R], a, b R) int {
+	if col.Number != nil {
+		x, y := col.Number(a), col.Number(b)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(col.Value(a), col.Value(b))
+}
+
+// writeCSV emits rows with a header of column keys so CSV and JSON field
+// names line up.
+func writeCSV[R any](out io.Writer, columns []tableColumn[R], rows []R) error {
+	w := csv.NewWriter(out)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Key
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, col := range columns {
+			record[i] = col.Value(row)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestColumnKeysMatchJSONFields(t *testing.T) {
+	// CSV headers and JSON field names must agree, column for column.
+	typ := reflect.TypeOf(historyRow{})
+	if typ.NumField() != len(columnRegistry) {
+		t.Fatalf("historyRow has %d fields but the registry %d columns", typ.NumField(), len(columnRegistry))
+	}
+	for i, col := range columnRegistry {
+		if tag := typ.Field(i).Tag.Get("json"); tag != col.Key {
+			t.Errorf("column %d: key %q, JSON field %q", i, col.Key, tag)
+		}
+	}
+}
+
+func TestSelectColumnsResolvesAliases(t *testing.T) {
+	cols, err := selectColumns([]string{"round", "opp", "min", "xg", "pts", "PTS"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var keys []string
+	for _, col := range cols {
+		keys = append(keys, col.Key)
+	}
+	if got := strings.Join(keys, ","); got != "round,opponent,minutes,xg,points" {
+		t.Fatalf("unexpected columns %s", got)
+	}
+
+	if _, err := selectColumns([]string{"bogus"}); err == nil {
+		t.Fatal("expected error for unknown column")
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	rows := []historyRow{
+		{Round: 1, Minutes: 90, Points: 2},
+		{Round: 2, Minutes: 60, Points: 8},
+		{Round: 3, Minutes: 90, Points: 8},
+	}
+	keys, err := parseSortKeys("pts:desc,min")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sortRows(rows, keys)
+	if rows[0].Round != 2 || rows[1].Round != 3 || rows[2].Round != 1 {
+		t.Fatalf("unexpected order: %+v", rows)
+	}
+
+	if _, err := parseSortKeys("pts:sideways"); err == nil {
+		t.Fatal("expected error for invalid direction")
+	}
+}
+
+func TestWriteCSVUsesColumnKeys(t *testing.T) {
+	var buf bytes.Buffer
+	cols := mustSelectColumns([]string{"gw", "xgi", "pts"})
+	if err := writeCSV(&buf, cols, []historyRow{{Round: 4, XGI: 0.456, Points: 9}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "round,xgi,points\n4,0.46,9\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+}
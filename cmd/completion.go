@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lpoulter1/fpl-cli/internal/aliases"
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds the network fallback used when nothing has been
+// cached on disk yet; after the first fetch completion never waits on the API.
+const completionTimeout = 5 * time.Second
+
+// fallbackGameweeks is offered for --gw when the cached bootstrap predates
+// event data.
+const fallbackGameweeks = 38
+
+func init() {
+	rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+		[]string{outputTable, outputJSON, outputCSV, outputNDJSON}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("color", cobra.FixedCompletions(
+		[]string{colorAuto, colorAlways, colorNever}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// completionIndex loads bootstrap data for completion, preferring the on-disk
+// cache regardless of age. It returns nil when no data is available.
+func completionIndex(cmd *cobra.Command) *fpl.PlayerIndex {
+	// Cobra's __complete skips PersistentPreRunE, so apply the configured
+	// base URL and cache TTL here; completion never fails on bad config.
+	if err := applyConfig(cmd); err != nil {
+		cobra.CompDebugln(fmt.Sprintf("apply config: %v", err), true)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	bootstrap, err := newClient().CachedBootstrap(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("load bootstrap: %v", err), true)
+		return nil
+	}
+	return fpl.NewPlayerIndex(bootstrap)
+}
+
+// registerPlayerCompletions wires the dynamic --name, --team, --position,
+// --gw, --columns and --sort completions for fpl player.
+func registerPlayerCompletions(cmd *cobra.Command, opts *playerOptions) {
+	cmd.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ix := completionIndex(cmd)
+		if ix == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		query := fpl.PlayerQuery{}
+		if opts.team != "" {
+			if team, err := ix.ResolveTeam(opts.team); err == nil {
+				query.TeamID = team.ID
+			}
+		}
+		if opts.position != "" {
+			if pos, err := ix.ResolvePosition(opts.position); err == nil {
+				query.ElementTypeID = pos.ID
+			}
+		}
+		completions := playerNameCompletions(ix, toComplete, query.Matches)
+		if store, err := aliases.Open(); err == nil {
+			completions = append(completions, aliasCompletions(store, toComplete)...)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("team", completeTeams)
+	cmd.RegisterFlagCompletionFunc("position", completePositions)
+	cmd.RegisterFlagCompletionFunc("gw", completeGameweeks)
+	cmd.RegisterFlagCompletionFunc("columns", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return listCompletions(columnKeys(), toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	})
+	cmd.RegisterFlagCompletionFunc("sort", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var terms []string
+		for _, key := range columnKeys() {
+			terms = append(terms, key, key+":desc")
+		}
+		return listCompletions(terms, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	})
+}
+
+func completeTeams(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ix := completionIndex(cmd)
+	if ix == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []string
+	for _, t := range ix.Bootstrap().Teams {
+		if strings.HasPrefix(strings.ToUpper(t.ShortName), strings.ToUpper(toComplete)) {
+			out = append(out, t.ShortName+"\t"+t.Name)
+		}
+	}
+	sort.Strings(out)
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completePositions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ix := completionIndex(cmd)
+	if ix == nil {
+		return []string{"GKP", "DEF", "MID", "FWD"}, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []string
+	for _, et := range ix.Bootstrap().ElementTypes {
+		out = append(out, et.SingularNameShort+"\t"+et.PluralName)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeGameweeks(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var events []fpl.Event
+	if ix := completionIndex(cmd); ix != nil {
+		events = ix.Bootstrap().Events
+	}
+	return gameweekCompletions(events, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// playerNameCompletions returns web names whose web, second, full or
+// known-as name starts with toComplete, ignoring case and accents. Web names
+// shared by several candidates get their club appended so the completed
+// value resolves to a single player.
+func playerNameCompletions(ix *fpl.PlayerIndex, toComplete string, keep func(*fpl.Element) bool) []string {
+	prefix := fpl.NormalizeName(toComplete)
+	elements := ix.Bootstrap().Elements
+
+	shared := make(map[string]int)
+	for i := range elements {
+		if keep(&elements[i]) {
+			shared[fpl.NormalizeName(elements[i].WebName)]++
+		}
+	}
+
+	seen := make(map[string]struct{})
+	var out []string
+	for i := range elements {
+		el := &elements[i]
+		if !keep(el) || !namePrefixMatch(el, prefix) {
+			continue
+		}
+		team, pos := "???", "???"
+		if t := ix.Team(el.Team); t != nil {
+			team = t.ShortName
+		}
+		if et := ix.Position(el.ElementType); et != nil {
+			pos = et.SingularNameShort
+		}
+		value := el.WebName
+		if shared[fpl.NormalizeName(el.WebName)] > 1 {
+			value += " " + team
+		}
+		if _, dup := seen[value]; dup {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, fmt.Sprintf("%s\t%s %s £%.1f", value, team, pos, float64(el.NowCost)/10.0))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func namePrefixMatch(el *fpl.Element, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	for _, name := range []string{el.WebName, el.SecondName, el.FirstName + " " + el.SecondName, el.KnownAs} {
+		if strings.HasPrefix(fpl.NormalizeName(name), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func aliasCompletions(store *aliases.Store, toComplete string) []string {
+	prefix := fpl.NormalizeName(toComplete)
+	var out []string
+	for _, a := range store.Aliases {
+		if strings.HasPrefix(fpl.NormalizeName(a.Name), prefix) {
+			out = append(out, a.Name+"\talias for "+a.Player)
+		}
+	}
+	return out
+}
+
+// gameweekCompletions offers started gameweeks for the last term of a --gw
+// value, keeping any earlier terms such as "1-3," intact.
+func gameweekCompletions(events []fpl.Event, toComplete string) []string {
+	cut := strings.LastIndexAny(toComplete, ",|-")
+	head, partial := toComplete[:cut+1], toComplete[cut+1:]
+
+	var out []string
+	add := func(id int, desc string) {
+		if value := strconv.Itoa(id); strings.HasPrefix(value, partial) {
+			out = append(out, head+value+"\t"+desc)
+		}
+	}
+	if len(events) == 0 {
+		for gw := 1; gw <= fallbackGameweeks; gw++ {
+			add(gw, fmt.Sprintf("Gameweek %d", gw))
+		}
+		return out
+	}
+	for _, ev := range events {
+		if ev.Finished || ev.IsCurrent {
+			add(ev.ID, ev.Name)
+		}
+	}
+	return out
+}
+
+// listCompletions completes the last entry of a comma-separated list.
+func listCompletions(values []string, toComplete string) []string {
+	cut := strings.LastIndex(toComplete, ",")
+	head, partial := toComplete[:cut+1], strings.ToLower(toComplete[cut+1:])
+	var out []string
+	for _, v := range values {
+		if strings.HasPrefix(v, partial) {
+			out = append(out, head+v)
+		}
+	}
+	return out
+}
+
+// completeUpcomingGameweeks offers next, next3 and next5 plus the gameweeks
+// whose deadlines have not passed, for commands that look ahead.
+func completeUpcomingGameweeks(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	out := listCompletions([]string{"next\tNext gameweek", "next3\tNext 3 gameweeks", "next5\tNext 5 gameweeks"}, toComplete)
+	if ix := completionIndex(cmd); ix != nil {
+		for _, ev := range ix.Bootstrap().Events {
+			if value := strconv.Itoa(ev.ID); !ev.Finished && !ev.IsCurrent && strings.HasPrefix(value, toComplete) {
+				out = append(out, value+"\t"+ev.Name)
+			}
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestPlayerNameCompletions(t *testing.T) {
+	ix := fpl.NewPlayerIndex(&fpl.BootstrapStatic{
+		Elements: []fpl.Element{
+			{ID: 1, WebName: "Ødegaard", FirstName: "Martin", SecondName: "Ødegaard", Team: 1, ElementType: 3, NowCost: 85},
+			{ID: 2, WebName: "Johnson", FirstName: "Brennan", SecondName: "Johnson", Team: 2, ElementType: 3, NowCost: 65},
+			{ID: 3, WebName: "Johnson", FirstName: "Ben", SecondName: "Johnson", Team: 1, ElementType: 2, NowCost: 45},
+			{ID: 4, WebName: "Salah", FirstName: "Mohamed", SecondName: "Salah", Team: 2, ElementType: 3, NowCost: 130},
+		},
+		Teams:        []fpl.Team{{ID: 1, ShortName: "ARS"}, {ID: 2, ShortName: "TOT"}},
+		ElementTypes: []fpl.ElementType{{ID: 2, SingularNameShort: "DEF"}, {ID: 3, SingularNameShort: "MID"}},
+	})
+	all := func(*fpl.Element) bool { return true }
+
+	if got, want := playerNameCompletions(ix, "ode", all), []string{"Ødegaard\tARS MID £8.5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("accent-insensitive prefix: got %q, want %q", got, want)
+	}
+	if got, want := playerNameCompletions(ix, "jo", all), []string{"Johnson ARS\tARS DEF £4.5", "Johnson TOT\tTOT MID £6.5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("shared web names: got %q, want %q", got, want)
+	}
+	if got, want := playerNameCompletions(ix, "mohamed", all), []string{"Salah\tTOT MID £13.0"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("full name prefix: got %q, want %q", got, want)
+	}
+
+	spurs := fpl.PlayerQuery{TeamID: 2}
+	if got, want := playerNameCompletions(ix, "jo", spurs.Matches), []string{"Johnson\tTOT MID £6.5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("team filter: got %q, want %q", got, want)
+	}
+}
+
+func TestGameweekCompletions(t *testing.T) {
+	events := []fpl.Event{
+		{ID: 1, Name: "Gameweek 1", Finished: true},
+		{ID: 2, Name: "Gameweek 2", IsCurrent: true},
+		{ID: 3, Name: "Gameweek 3", IsNext: true},
+	}
+	if got, want := gameweekCompletions(events, ""), []string{"1\tGameweek 1", "2\tGameweek 2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := gameweekCompletions(events, "1-"), []string{"1-1\tGameweek 1", "1-2\tGameweek 2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("range: got %q, want %q", got, want)
+	}
+	if got := gameweekCompletions(nil, "3"); len(got) != 10 || got[0] != "3\tGameweek 3" || got[9] != "38\tGameweek 38" {
+		t.Fatalf("fallback: got %q", got)
+	}
+}
+
+func TestListCompletions(t *testing.T) {
+	got := listCompletions([]string{"round", "minutes", "points"}, "round,m")
+	if want := []string{"round,minutes"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lpoulter1/fpl-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// configListVersion is the schema_version of configList JSON output.
+const configListVersion = 1
+
+// configFlags maps config keys to the global flag that overrides them.
+var configFlags = map[string]string{
+	"output":    "output",
+	"cache_ttl": "cache-ttl",
+	"base_url":  "base-url",
+	"timezone":  "timezone",
+}
+
+// applyConfig fills rootOpts from FPL_* variables and the config file for
+// every setting whose flag was not given explicitly. Invalid file values are
+// ignored with a warning; invalid environment values are errors, except for
+// fpl config and fpl profile, which must keep working to repair them.
+func applyConfig(cmd *cobra.Command) error {
+	cfg, err := config.Open()
+	if err != nil {
+		return err
+	}
+	profile := cfg.ActiveProfile(rootOpts.profile)
+	repair := managesConfig(cmd) || managesProfiles(cmd)
+	if repair {
+		// fpl profile must also keep working when the selected profile is
+		// the one being created or was just removed.
+		var err error
+		if profile, err = existingProfile(cfg); err != nil && !managesProfiles(cmd) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v; ignoring it\n", err)
+		}
+	}
+	eff, sources, err := cfg.Effective(profile)
+	if err != nil {
+		return err
+	}
+	for _, w := range eff.Warnings() {
+		if w.Source == config.SourceEnv && !repair {
+			return w.Err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", w)
+	}
+	configured := func(key string) bool {
+		return sources[key] != config.SourceDefault && !cmd.Flags().Changed(configFlags[key])
+	}
+
+	if configured("output") {
+		rootOpts.output = eff.Output
+	}
+	if configured("cache_ttl") {
+		ttl, err := time.ParseDuration(eff.CacheTTL)
+		if err != nil {
+			return err
+		}
+		rootOpts.cacheTTL = ttl
+	}
+	if configured("base_url") {
+		rootOpts.baseURL = eff.BaseURL
+	}
+	if configured("timezone") {
+		rootOpts.timezone = eff.Timezone
+	}
+	rootOpts.entry = eff.Entry
+	rootOpts.leagues = eff.Leagues
+	rootOpts.rivals = eff.Rivals
+
+	if _, err := time.LoadLocation(rootOpts.timezone); err != nil {
+		return fmt.Errorf("invalid --timezone %q: %w", rootOpts.timezone, err)
+	}
+	return nil
+}
+
+// existingProfile returns the active profile, or "" with an error when it
+// is not defined.
+func existingProfile(cfg *config.Config) (string, error) {
+	profile := cfg.ActiveProfile(rootOpts.profile)
+	if profile == "" {
+		return "", nil
+	}
+	if _, err := cfg.LookupProfile(profile); err != nil {
+		return "", err
+	}
+	return profile, nil
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and change saved defaults",
+		Long: `Read and change defaults stored in ~/.config/fpl/config.yaml (under the OS
+config directory, honoring $XDG_CONFIG_HOME; set FPL_CONFIG to use another file).
+
+Every setting can also come from an FPL_* environment variable. Precedence is:
+command-line flag, then environment variable, then the active profile (for
+entry, leagues and rivals; see fpl profile), then config file, then the
+built-in default.`,
+		Example: `  fpl config list
+  fpl config set output json
+  fpl config set leagues 314,2718
+  fpl config get cache_ttl
+  fpl config unset base_url`,
+		Annotations: map[string]string{configAdminAnnotation: ""},
+	}
+	cmd.AddCommand(newConfigListCmd(), newConfigGetCmd(), newConfigSetCmd(), newConfigUnsetCmd())
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newConfigCmd())
+	registerReportSchema(reportSchema{
+		Name:        "config-list",
+		Version:     configListVersion,
+		Description: "Every setting with its effective value and source from fpl config list --json",
+		Sample:      configList{},
+	})
+}
+
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []string
+	for _, k := range config.Keys {
+		out = append(out, k.Name+"\t"+k.Description)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+type configEntry struct {
+	Key         string        `json:"key"`
+	Value       string        `json:"value"`
+	Source      config.Source `json:"source"`
+	Env         string        `json:"env"`
+	Description string        `json:"description"`
+}
+
+type configList struct {
+	SchemaVersion int           `json:"schema_version"`
+	Path          string        `json:"path"`
+	Settings      []configEntry `json:"settings"`
+}
+
+func loadConfigList() (configList, error) {
+	list := configList{SchemaVersion: configListVersion}
+	cfg, err := config.Open()
+	if err != nil {
+		return list, err
+	}
+	list.Path = cfg.Path()
+	// applyConfig has already warned about a missing profile.
+	profile, _ := existingProfile(cfg)
+	eff, sources, err := cfg.Effective(profile)
+	if err != nil {
+		return list, err
+	}
+	list.Settings = make([]configEntry, 0, len(config.Keys))
+	for _, k := range config.Keys {
+		list.Settings = append(list.Settings, configEntry{
+			Key:         k.Name,
+			Value:       k.Value(eff),
+			Source:      sources[k.Name],
+			Env:         k.Env,
+			Description: k.Description,
+		})
+	}
+	return list, nil
+}
+
+func newConfigListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show every setting with its effective value and source",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := loadConfigList()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if outputFormat() == outputJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(list)
+			}
+			tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "Key\tValue\tSource\tEnv")
+			for _, e := range list.Settings {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Key, dashIfEmpty(e.Value), e.Source, e.Env)
+			}
+			return tw.Flush()
+		},
+	}
+}
+
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print the effective value of a setting",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, ok := config.LookupKey(args[0])
+			if !ok {
+				return fmt.Errorf("unknown config key %q", args[0])
+			}
+			list, err := loadConfigList()
+			if err != nil {
+				return err
+			}
+			for _, e := range list.Settings {
+				if e.Key == k.Name {
+					fmt.Fprintln(cmd.OutOrStdout(), e.Value)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "set <key> <value>",
+		Short:             "Save a setting to the config file",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Open()
+			if err != nil {
+				return err
+			}
+			if err := cfg.Set(args[0], args[1]); err != nil {
+				return err
+			}
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			k, _ := config.LookupKey(args[0])
+			value, _ := cfg.Get(k.Name)
+			fmt.Fprintf(cmd.OutOrStdout(), "Set %s = %s in %s\n", k.Name, value, cfg.Path())
+			warnEnvOverride(cmd, k)
+			return nil
+		},
+	}
+}
+
+func newConfigUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "unset <key>",
+		Short:             "Remove a setting from the config file",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Open()
+			if err != nil {
+				return err
+			}
+			if err := cfg.Unset(args[0]); err != nil {
+				return err
+			}
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			k, _ := config.LookupKey(args[0])
+			fmt.Fprintf(cmd.OutOrStdout(), "Unset %s in %s\n", k.Name, cfg.Path())
+			warnEnvOverride(cmd, k)
+			return nil
+		},
+	}
+}
+
+func warnEnvOverride(cmd *cobra.Command, k config.Key) {
+	if v, ok := os.LookupEnv(k.Env); ok && v != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "note: %s is set and takes precedence over the config file\n", k.Env)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// executeRoot runs fpl with args against a config file holding yaml and
+// restores the global options and flags afterwards.
+func executeRoot(t *testing.T, yaml string, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("FPL_CONFIG", path)
+	for _, env := range []string{"FPL_PROFILE", "FPL_OUTPUT", "FPL_CACHE_TTL", "FPL_BASE_URL", "FPL_TIMEZONE", "FPL_ENTRY", "FPL_LEAGUES", "FPL_RIVALS"} {
+		if _, set := os.LookupEnv(env); !set {
+			t.Setenv(env, "")
+		}
+	}
+
+	saved := *rootOpts
+	t.Cleanup(func() {
+		rootCmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+		*rootOpts = saved
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+	var out, errOut bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&errOut)
+	rootCmd.SetArgs(args)
+	err = rootCmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func TestApplyConfigPrecedence(t *testing.T) {
+	yaml := `output: csv
+cache_ttl: 1m
+entry: 1
+leagues: [10]
+rivals: [100]
+profile: alice
+profiles:
+  alice:
+    entry: 2
+    leagues: [20]
+`
+	t.Setenv("FPL_CACHE_TTL", "2m")
+	t.Setenv("FPL_LEAGUES", "30")
+	if _, stderr, err := executeRoot(t, yaml, "--output", "json", "config", "list"); err != nil {
+		t.Fatalf("unexpected error: %v (%s)", err, stderr)
+	}
+	// Flag beats file, env beats file and profile, profile beats file, and
+	// the file fills in what nothing else sets.
+	if rootOpts.output != outputJSON {
+		t.Errorf("expected --output to win, got %q", rootOpts.output)
+	}
+	if rootOpts.cacheTTL != 2*time.Minute {
+		t.Errorf("expected FPL_CACHE_TTL to win, got %s", rootOpts.cacheTTL)
+	}
+	if rootOpts.entry != 2 {
+		t.Errorf("expected the profile's entry, got %d", rootOpts.entry)
+	}
+	if !reflect.DeepEqual(rootOpts.leagues, []int{30}) {
+		t.Errorf("expected FPL_LEAGUES over the profile, got %v", rootOpts.leagues)
+	}
+	if !reflect.DeepEqual(rootOpts.rivals, []int{100}) {
+		t.Errorf("expected the file's rivals, got %v", rootOpts.rivals)
+	}
+}
+
+func TestApplyConfigInvalidValues(t *testing.T) {
+	// A bad file value is ignored with a warning by every command.
+	_, stderr, err := executeRoot(t, "cache_ttl: banana\noutput: csv\n", "schema")
+	if err != nil {
+		t.Fatalf("a bad file value should not fail commands: %v", err)
+	}
+	if !strings.Contains(stderr, "cache_ttl") || rootOpts.output != outputCSV {
+		t.Fatalf("expected a cache_ttl warning and the rest applied, got %q and output %q", stderr, rootOpts.output)
+	}
+
+	// A bad environment value fails ordinary commands, but fpl config still
+	// runs so it can be inspected and repaired.
+	t.Setenv("FPL_OUTPUT", "xml")
+	if _, _, err := executeRoot(t, "", "schema"); err == nil || !strings.Contains(err.Error(), "FPL_OUTPUT") {
+		t.Fatalf("expected FPL_OUTPUT to be rejected, got %v", err)
+	}
+	stdout, stderr, err := executeRoot(t, "cache_ttl: banana\n", "config", "list")
+	if err != nil {
+		t.Fatalf("fpl config list should survive bad values: %v", err)
+	}
+	if !strings.Contains(stderr, "FPL_OUTPUT") || !strings.Contains(stderr, "cache_ttl") || !strings.Contains(stdout, "cache_ttl") {
+		t.Fatalf("expected warnings for both values and a listing, got %q / %q", stderr, stdout)
+	}
+	if _, _, err := executeRoot(t, "cache_ttl: banana\n", "config", "unset", "cache_ttl"); err != nil {
+		t.Fatalf("fpl config unset should survive bad values: %v", err)
+	}
+}
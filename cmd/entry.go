@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/spf13/cobra"
+)
+
+func addEntryFlag(cmd *cobra.Command, entry *int) {
+	cmd.Flags().IntVar(entry, "entry", 0, "manager entry ID (defaults to the configured entry)")
+}
+
+// resolveEntry returns the --entry flag, falling back to the entry from the
+// environment, active profile or config file.
+func resolveEntry(cmd *cobra.Command, entry int) (int, error) {
+	if !cmd.Flags().Changed("entry") {
+		entry = rootOpts.entry
+	}
+	if entry <= 0 {
+		return 0, errors.New("no manager entry: pass --entry or save one with fpl config set entry <id>")
+	}
+	return entry, nil
+}
+
+// managerPicks is a manager's latest squad as picked.
+type managerPicks struct {
+	Entry   *fpl.Entry
+	History *fpl.EntryHistory
+	// Gameweek is the gameweek the picks were read from.
+	Gameweek int
+	Picks    *fpl.EntryPicks
+	freeHits map[int]bool
+}
+
+// managerSquad is a manager's latest squad with what each player would sell
+// for.
+type managerSquad struct {
+	*managerPicks
+	// Purchase and Selling map element IDs to prices in 0.1m.
+	Purchase map[int]int
+	Selling  map[int]int
+	Bank     int
+}
+
+// loadManagerPicks fetches the squad an entry takes into the next gameweek.
+// A free hit squad reverts after its gameweek, so the picks before it are
+// used instead. Transfers already made for the next gameweek stay private
+// until its deadline.
+func loadManagerPicks(ctx context.Context, client *fpl.Client, id int) (*managerPicks, error) {
+	entry, err := client.Entry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.CurrentEvent == 0 {
+		return nil, fmt.Errorf("entry %d has not played a gameweek yet", id)
+	}
+	history, err := client.EntryHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	freeHits := make(map[int]bool)
+	for _, chip := range history.Chips {
+		if chip.Name == "freehit" {
+			freeHits[chip.Event] = true
+		}
+	}
+
+	gw := entry.CurrentEvent
+	if freeHits[gw] && gw > entry.StartedEvent {
+		gw--
+	}
+	picks, err := client.EntryPicks(ctx, id, gw)
+	if err != nil {
+		return nil, err
+	}
+	return &managerPicks{Entry: entry, History: history, Gameweek: gw, Picks: picks, freeHits: freeHits}, nil
+}
+
+// loadManagerSquad fetches an entry's latest picks and works out selling
+// prices from their transfer history.
+func loadManagerSquad(ctx context.Context, client *fpl.Client, ix *fpl.PlayerIndex, id int) (*managerSquad, error) {
+	mp, err := loadManagerPicks(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+	entry, picks, gw, freeHits := mp.Entry, mp.Picks, mp.Gameweek, mp.freeHits
+	transfers, err := client.EntryTransfers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	squad := &managerSquad{
+		managerPicks: mp,
+		Purchase:     make(map[int]int, len(picks.Picks)),
+		Selling:      make(map[int]int, len(picks.Picks)),
+		Bank:         picks.EntryHistory.Bank,
+	}
+	latest := make(map[int]fpl.Transfer)
+	for _, t := range transfers {
+		if freeHits[t.Event] || t.Event > gw {
+			continue
+		}
+		if prev, ok := latest[t.ElementIn]; !ok || t.Time.After(prev.Time) {
+			latest[t.ElementIn] = t
+		}
+	}
+	var originals []int
+	for _, p := range picks.Picks {
+		if t, ok := latest[p.Element]; ok {
+			squad.Purchase[p.Element] = t.ElementInCost
+		} else {
+			originals = append(originals, p.Element)
+		}
+	}
+	// Players kept since the entry started were bought at their price in
+	// that gameweek, which only their match history records.
+	summaries, err := fetchPlayerSummaries(ctx, client, originals)
+	if err != nil {
+		return nil, err
+	}
+	for _, elementID := range originals {
+		squad.Purchase[elementID] = startPrice(summaries[elementID], entry.StartedEvent)
+	}
+	for _, p := range picks.Picks {
+		now := squad.Purchase[p.Element]
+		if el := ix.Player(p.Element); el != nil {
+			now = el.NowCost
+		}
+		if squad.Purchase[p.Element] == 0 {
+			squad.Purchase[p.Element] = now
+		}
+		squad.Selling[p.Element] = fpl.SellingPrice(squad.Purchase[p.Element], now)
+	}
+	return squad, nil
+}
+
+// startPrice is a player's price in gameweek gw, or zero when their history
+// does not reach back that far.
+func startPrice(summary *fpl.PlayerSummary, gw int) int {
+	if summary == nil {
+		return 0
+	}
+	for _, h := range summary.History {
+		if h.Round >= gw {
+			return h.Value
+		}
+	}
+	return 0
+}
+
+// fetchPlayerSummaries loads element summaries for ids using a bounded pool
+// of workers.
+func fetchPlayerSummaries(ctx context.Context, client *fpl.Client, ids []int) (map[int]*fpl.PlayerSummary, error) {
+	return fetchAll(ctx, ids, func(ctx context.Context, id int) (*fpl.PlayerSummary, error) {
+		summary, err := client.PlayerSummary(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("load player %d: %w", id, err)
+		}
+		return summary, nil
+	})
+}
+
+// fetchAll calls fetch for every key using a bounded pool of workers and
+// returns the results by key along with the first error.
+func fetchAll[K comparable, V any](ctx context.Context, keys []K, fetch func(context.Context, K) (V, error)) (map[K]V, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	results := make(map[K]V, len(keys))
+	sem := make(chan struct{}, summaryConcurrency)
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key K) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			v, err := fetch(ctx, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			results[key] = v
+		}(key)
+	}
+	wg.Wait()
+	return results, firstErr
+}
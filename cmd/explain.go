@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+// pointsExplanation is where a player's points came from, gameweek by
+// gameweek, from the live endpoint's explain data.
+type pointsExplanation struct {
+	Gameweeks []explainedGameweek `json:"gameweeks"`
+	// Totals sums the gameweeks shown, which is the season to date unless
+	// --gw narrows it.
+	Totals pointsScored `json:"totals"`
+}
+
+type explainedGameweek struct {
+	Gameweek  int          `json:"gameweek"`
+	Points    int          `json:"points"`
+	Breakdown pointsScored `json:"breakdown"`
+}
+
+// pointsScored splits actual points by scoring category, like
+// pointsBreakdown does for projections.
+type pointsScored struct {
+	Appearance  int `json:"appearance"`
+	Goals       int `json:"goals"`
+	Assists     int `json:"assists"`
+	CleanSheets int `json:"clean_sheets"`
+	Conceded    int `json:"goals_conceded"`
+	Saves       int `json:"saves"`
+	Bonus       int `json:"bonus"`
+	Cards       int `json:"cards"`
+	Defensive   int `json:"defensive_contribution"`
+	Other       int `json:"other"`
+}
+
+// scoringCategories lists the categories in display order.
+var scoringCategories = []struct {
+	key, header string
+	get         func(pointsScored) int
+}{
+	{fpl.CategoryAppearance, "App", func(p pointsScored) int { return p.Appearance }},
+	{fpl.CategoryGoals, "Goals", func(p pointsScored) int { return p.Goals }},
+	{fpl.CategoryAssists, "Ast", func(p pointsScored) int { return p.Assists }},
+	{fpl.CategoryCleanSheets, "CS", func(p pointsScored) int { return p.CleanSheets }},
+	{fpl.CategoryConceded, "GC", func(p pointsScored) int { return p.Conceded }},
+	{fpl.CategorySaves, "Saves", func(p pointsScored) int { return p.Saves }},
+	{fpl.CategoryBonus, "Bonus", func(p pointsScored) int { return p.Bonus }},
+	{fpl.CategoryCards, "Cards", func(p pointsScored) int { return p.Cards }},
+	{fpl.CategoryDefensive, "DefCon", func(p pointsScored) int { return p.Defensive }},
+	{fpl.CategoryOther, "Other", func(p pointsScored) int { return p.Other }},
+}
+
+func newPointsScored(byCategory map[string]int) pointsScored {
+	return pointsScored{
+		Appearance:  byCategory[fpl.CategoryAppearance],
+		Goals:       byCategory[fpl.CategoryGoals],
+		Assists:     byCategory[fpl.CategoryAssists],
+		CleanSheets: byCategory[fpl.CategoryCleanSheets],
+		Conceded:    byCategory[fpl.CategoryConceded],
+		Saves:       byCategory[fpl.CategorySaves],
+		Bonus:       byCategory[fpl.CategoryBonus],
+		Cards:       byCategory[fpl.CategoryCards],
+		Defensive:   byCategory[fpl.CategoryDefensive],
+		Other:       byCategory[fpl.CategoryOther],
+	}
+}
+
+func (p *pointsScored) add(o pointsScored) {
+	p.Appearance += o.Appearance
+	p.Goals += o.Goals
+	p.Assists += o.Assists
+	p.CleanSheets += o.CleanSheets
+	p.Conceded += o.Conceded
+	p.Saves += o.Saves
+	p.Bonus += o.Bonus
+	p.Cards += o.Cards
+	p.Defensive += o.Defensive
+	p.Other += o.Other
+}
+
+func (p pointsScored) total() int {
+	total := 0
+	for _, c := range scoringCategories {
+		total += c.get(p)
+	}
+	return total
+}
+
+// loadPointsExplanation fetches the live data of every gameweek in rounds
+// and breaks the player's points down by category. Gameweeks whose data FPL
+// has checked are served from the on-disk cache once fetched.
+func loadPointsExplanation(ctx context.Context, client *fpl.Client, events []fpl.Event, player int, rounds []int) (*pointsExplanation, error) {
+	checked := make(map[int]bool, len(events))
+	for _, ev := range events {
+		checked[ev.ID] = ev.DataChecked
+	}
+	var weeks []int
+	seen := make(map[int]bool, len(rounds))
+	for _, gw := range rounds {
+		if !seen[gw] {
+			seen[gw] = true
+			weeks = append(weeks, gw)
+		}
+	}
+	sort.Ints(weeks)
+	lives, err := fetchAll(ctx, weeks, func(ctx context.Context, gw int) (*fpl.Live, error) {
+		if checked[gw] {
+			return client.FinishedEventLive(ctx, gw)
+		}
+		return client.EventLive(ctx, gw)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return explainPoints(player, weeks, lives), nil
+}
+
+func explainPoints(player int, weeks []int, lives map[int]*fpl.Live) *pointsExplanation {
+	explanation := &pointsExplanation{Gameweeks: []explainedGameweek{}}
+	for _, gw := range weeks {
+		week := explainedGameweek{Gameweek: gw}
+		for i := range lives[gw].Elements {
+			if el := &lives[gw].Elements[i]; el.ID == player {
+				week.Breakdown = newPointsScored(el.PointsByCategory())
+				break
+			}
+		}
+		week.Points = week.Breakdown.total()
+		explanation.Totals.add(week.Breakdown)
+		explanation.Gameweeks = append(explanation.Gameweeks, week)
+	}
+	return explanation
+}
+
+// printPointsExplanation tabulates the categories the player has scored in
+// and ranks their share of the total.
+func printPointsExplanation(out io.Writer, explanation *pointsExplanation, colors palette) {
+	columns := []tableColumn[explainedGameweek]{
+		intColumn("gameweek", "GW", 0, func(w explainedGameweek) int { return w.Gameweek }),
+		intColumn("points", "Pts", 0, func(w explainedGameweek) int { return w.Points }),
+	}
+	type share struct {
+		name   string
+		points int
+	}
+	var shares []share
+	for _, c := range scoringCategories {
+		c := c
+		scored := false
+		for _, w := range explanation.Gameweeks {
+			if c.get(w.Breakdown) != 0 {
+				scored = true
+				break
+			}
+		}
+		if !scored {
+			continue
+		}
+		columns = append(columns, intColumn(c.key, c.header, 1, func(w explainedGameweek) int { return c.get(w.Breakdown) }))
+		if points := c.get(explanation.Totals); points != 0 {
+			shares = append(shares, share{c.header, points})
+		}
+	}
+
+	fmt.Fprintln(out, colors.bold("\nPoints by category"))
+	renderTable(out, fitColumns(columns, explanation.Gameweeks, terminalWidth(out)), explanation.Gameweeks, colors)
+
+	total := explanation.Totals.total()
+	if total <= 0 || len(shares) == 0 {
+		return
+	}
+	sort.SliceStable(shares, func(i, j int) bool { return shares[i].points > shares[j].points })
+	parts := make([]string, len(shares))
+	for i, s := range shares {
+		parts[i] = fmt.Sprintf("%s %d (%.0f%%)", s.name, s.points, 100*float64(s.points)/float64(total))
+	}
+	fmt.Fprintf(out, "By category: %s\n", strings.Join(parts, " | "))
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestExplainPoints(t *testing.T) {
+	stat := func(identifier string, points int) fpl.ExplainStat {
+		return fpl.ExplainStat{Identifier: identifier, Points: points, Value: 1}
+	}
+	lives := map[int]*fpl.Live{
+		1: {Elements: []fpl.LiveElement{
+			{ID: 8, Explain: []fpl.FixtureExplain{{Stats: []fpl.ExplainStat{stat("minutes", 2)}}}},
+			{ID: 7, Explain: []fpl.FixtureExplain{{Stats: []fpl.ExplainStat{
+				stat("minutes", 2), stat("goals_scored", 5), stat("bonus", 3),
+			}}}},
+		}},
+		// Player 7 did not feature in gameweek 2.
+		2: {Elements: []fpl.LiveElement{{ID: 8}}},
+		// A double gameweek with a booking in the second fixture.
+		3: {Elements: []fpl.LiveElement{{ID: 7, Explain: []fpl.FixtureExplain{
+			{Stats: []fpl.ExplainStat{stat("minutes", 2), stat("assists", 3)}},
+			{Stats: []fpl.ExplainStat{stat("minutes", 1), stat("yellow_cards", -1)}},
+		}}}},
+	}
+
+	explanation := explainPoints(7, []int{1, 2, 3}, lives)
+	if len(explanation.Gameweeks) != 3 {
+		t.Fatalf("expected three gameweeks, got %+v", explanation.Gameweeks)
+	}
+	if week := explanation.Gameweeks[1]; week.Gameweek != 2 || week.Points != 0 || week.Breakdown != (pointsScored{}) {
+		t.Fatalf("expected a zero gameweek 2 for a missing player, got %+v", week)
+	}
+	if got := explanation.Gameweeks[2].Breakdown; got.Appearance != 3 || got.Assists != 3 || got.Cards != -1 {
+		t.Fatalf("expected both fixtures of gameweek 3 counted, got %+v", got)
+	}
+
+	want := pointsScored{Appearance: 5, Goals: 5, Assists: 3, Bonus: 3, Cards: -1}
+	if explanation.Totals != want {
+		t.Fatalf("totals = %+v, want %+v", explanation.Totals, want)
+	}
+	sum := 0
+	for _, week := range explanation.Gameweeks {
+		sum += week.Points
+	}
+	if sum != 15 || explanation.Totals.total() != sum {
+		t.Fatalf("expected gameweek points and totals to add up to 15, got %d and %d", sum, explanation.Totals.total())
+	}
+}
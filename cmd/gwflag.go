@@ -14,13 +14,19 @@ type gwRange struct {
 
 type gwFlag struct {
 	ranges []gwRange
+	// next counts upcoming gameweeks requested with "next" or "nextN"; they
+	// become ranges once resolveNext knows the next gameweek.
+	next int
 }
 
 func (g *gwFlag) String() string {
-	if len(g.ranges) == 0 {
+	if len(g.ranges) == 0 && g.next == 0 {
 		return ""
 	}
-	parts := make([]string, 0, len(g.ranges))
+	parts := make([]string, 0, len(g.ranges)+1)
+	if g.next > 0 {
+		parts = append(parts, fmt.Sprintf("next%d", g.next))
+	}
 	for _, r := range g.ranges {
 		if r.Start == r.End {
 			parts = append(parts, fmt.Sprintf("%d", r.Start))
@@ -38,6 +44,13 @@ func (g *gwFlag) Set(value string) error {
 	}
 
 	for _, token := range splitTokens(value) {
+		if n, ok, err := parseNext(token); ok {
+			if err != nil {
+				return err
+			}
+			g.next = max(g.next, n)
+			continue
+		}
 		gr, err := parseGWRange(token)
 		if err != nil {
 			return err
@@ -96,6 +109,48 @@ func (g *gwFlag) Ranges() []gwRange {
 	return g.ranges
 }
 
+// relative reports whether any upcoming gameweeks are still unresolved.
+func (g *gwFlag) relative() bool {
+	return g.next > 0
+}
+
+// resolveNext turns "nextN" into the N gameweeks starting at next.
+func (g *gwFlag) resolveNext(next int) {
+	if g.next == 0 {
+		return
+	}
+	g.ranges = append(g.ranges, gwRange{Start: next, End: next + g.next - 1})
+	g.next = 0
+	g.normalize()
+}
+
+// gameweeks lists every gameweek in the ranges in order.
+func (g *gwFlag) gameweeks() []int {
+	var weeks []int
+	for _, r := range g.ranges {
+		for gw := r.Start; gw <= r.End; gw++ {
+			weeks = append(weeks, gw)
+		}
+	}
+	return weeks
+}
+
+// parseNext recognizes "next" and "nextN" tokens.
+func parseNext(token string) (int, bool, error) {
+	lower := strings.ToLower(strings.TrimSpace(token))
+	if !strings.HasPrefix(lower, "next") {
+		return 0, false, nil
+	}
+	if lower == "next" {
+		return 1, true, nil
+	}
+	n, err := strconv.Atoi(lower[len("next"):])
+	if err != nil || n <= 0 {
+		return 0, true, fmt.Errorf("invalid gameweek %q: expected next or nextN, like next3", token)
+	}
+	return n, true, nil
+}
+
 func parseGWRange(token string) (gwRange, error) {
 	token = strings.TrimSpace(token)
 	if token == "" {
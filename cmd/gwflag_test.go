@@ -43,3 +43,23 @@ func TestGWFlagInvalid(t *testing.T) {
 		t.Fatal("expected error for inverted range")
 	}
 }
+
+func TestGWFlagNext(t *testing.T) {
+	var flag gwFlag
+	if err := flag.Set("next3,12"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !flag.relative() || flag.String() != "next3,12" {
+		t.Fatalf("expected an unresolved next3, got %q", flag.String())
+	}
+	flag.resolveNext(9)
+	if flag.relative() || flag.String() != "9-12" {
+		t.Fatalf("expected next3 from GW9 to merge with 12, got %q", flag.String())
+	}
+	if got := flag.gameweeks(); len(got) != 4 || got[0] != 9 || got[3] != 12 {
+		t.Fatalf("unexpected gameweeks %v", got)
+	}
+	if err := flag.Set("next0"); err == nil {
+		t.Fatal("expected next0 to be rejected")
+	}
+}
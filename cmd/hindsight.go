@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/optimize"
+	"github.com/spf13/cobra"
+)
+
+// hindsightReportVersion is the schema_version of hindsightReport JSON output.
+const hindsightReportVersion = 1
+
+// hindsightWeekVersion is the schema_version of each fpl hindsight NDJSON
+// line.
+const hindsightWeekVersion = 1
+
+type hindsightOptions struct {
+	entry int
+	gws   gwFlag
+}
+
+type hindsightReport struct {
+	SchemaVersion int             `json:"schema_version"`
+	Entry         int             `json:"entry"`
+	EntryName     string          `json:"entry_name"`
+	Gameweeks     []hindsightWeek `json:"gameweeks"`
+	Totals        hindsightTotals `json:"totals"`
+}
+
+// hindsightWeek compares a gameweek's score with the best the squad could
+// have managed. Points are before transfer hits.
+type hindsightWeek struct {
+	Gameweek int    `json:"gameweek"`
+	Chip     string `json:"chip,omitempty"`
+	Points   int    `json:"points"`
+	// Best is the best XI's points plus the best captain's extra points;
+	// with Bench Boost every player counts.
+	Best          int               `json:"best"`
+	Missed        int               `json:"missed"`
+	BenchPoints   int               `json:"bench_points"`
+	BestFormation string            `json:"best_formation"`
+	BestXI        []hindsightPlayer `json:"best_xi"`
+	// Captain is whoever took the armband after any vice-captain promotion;
+	// it is omitted when neither captain played.
+	Captain       *hindsightPlayer `json:"captain,omitempty"`
+	BestCaptain   hindsightPlayer  `json:"best_captain"`
+	CaptainRegret int              `json:"captain_regret"`
+	AutoSubs      []hindsightSub   `json:"auto_subs"`
+	AutoSubPoints int              `json:"auto_sub_points"`
+	TransferCost  int              `json:"transfer_cost"`
+}
+
+// hindsightWeekLine is one gameweek as streamed with --output ndjson.
+type hindsightWeekLine struct {
+	SchemaVersion int `json:"schema_version"`
+	hindsightWeek
+}
+
+type hindsightPlayer struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Position string `json:"position"`
+	Points   int    `json:"points"`
+}
+
+type hindsightSub struct {
+	Out hindsightPlayer `json:"out"`
+	In  hindsightPlayer `json:"in"`
+}
+
+type hindsightTotals struct {
+	Points        int `json:"points"`
+	Best          int `json:"best"`
+	Missed        int `json:"missed"`
+	BenchPoints   int `json:"bench_points"`
+	CaptainRegret int `json:"captain_regret"`
+	AutoSubPoints int `json:"auto_sub_points"`
+	TransferCost  int `json:"transfer_cost"`
+}
+
+func newHindsightCmd() *cobra.Command {
+	opts := &hindsightOptions{}
+	cmd := &cobra.Command{
+		Use:   "hindsight",
+		Short: "Compare each gameweek with the best XI and captain from the same squad",
+		Long: `Look back over a manager's gameweeks and compare each score with the best XI
+and captain they could have picked from the same 15 players.
+
+For every gameweek it shows the points scored, the best possible score, the
+points left on the bench, captain regret (the extra points the best captain
+would have earned over the one who wore the armband, after any vice-captain
+promotion) and what automatic substitutions added. Triple Captain and Bench
+Boost are applied to the best score as well; points are before transfer hits.`,
+		Example: `  fpl hindsight --entry 123456
+  fpl hindsight --gw 1-10
+  fpl hindsight --gw 5 --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHindsight(cmd.Context(), cmd, opts)
+		},
+	}
+	addEntryFlag(cmd, &opts.entry)
+	cmd.Flags().Var(&opts.gws, "gw", "gameweeks to review: a gameweek or ranges like 1-10 (default all played)")
+	cmd.RegisterFlagCompletionFunc("gw", completeGameweeks)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newHindsightCmd())
+	registerReportSchema(reportSchema{
+		Name:        "hindsight",
+		Version:     hindsightReportVersion,
+		Description: "Best XI and captain regret per gameweek from fpl hindsight --json",
+		Sample:      hindsightReport{},
+	})
+	registerReportSchema(reportSchema{
+		Name:        "hindsight-week",
+		Version:     hindsightWeekVersion,
+		Description: "One gameweek's best XI and captain regret per line from fpl hindsight --output ndjson",
+		Sample:      hindsightWeekLine{},
+	})
+}
+
+func runHindsight(ctx context.Context, cmd *cobra.Command, opts *hindsightOptions) error {
+	if opts.gws.relative() {
+		return fmt.Errorf("--gw next selects upcoming gameweeks, which have not been played yet")
+	}
+	entryID, err := resolveEntry(cmd, opts.entry)
+	if err != nil {
+		return err
+	}
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+	entry, err := client.Entry(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	history, err := client.EntryHistory(ctx, entryID)
+	if err != nil {
+		return err
+	}
+
+	var played []fpl.EntryEvent
+	var weeks []int
+	for _, ev := range history.Current {
+		if opts.gws.includes(ev.Event) {
+			played = append(played, ev)
+			weeks = append(weeks, ev.Event)
+		}
+	}
+	if len(played) == 0 {
+		if len(opts.gws.Ranges()) > 0 {
+			return fmt.Errorf("entry %d played no gameweeks in %s", entryID, opts.gws.String())
+		}
+		return fmt.Errorf("entry %d has not played a gameweek yet", entryID)
+	}
+
+	picks, err := fetchAll(ctx, weeks, func(ctx context.Context, gw int) (*fpl.EntryPicks, error) {
+		return client.EntryPicks(ctx, entryID, gw)
+	})
+	if err != nil {
+		return err
+	}
+	live, err := fetchAll(ctx, weeks, func(ctx context.Context, gw int) (map[int]fpl.LiveStats, error) {
+		l, err := client.EventLive(ctx, gw)
+		if err != nil {
+			return nil, fmt.Errorf("load gameweek %d: %w", gw, err)
+		}
+		return l.Stats(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	report := hindsightReport{SchemaVersion: hindsightReportVersion, Entry: entryID, EntryName: entry.Name}
+	for _, ev := range played {
+		week, err := reviewGameweek(ix, ev, picks[ev.Event], live[ev.Event])
+		if err != nil {
+			return err
+		}
+		report.Gameweeks = append(report.Gameweeks, week)
+		t := &report.Totals
+		t.Points += week.Points
+		t.Best += week.Best
+		t.Missed += week.Missed
+		t.BenchPoints += week.BenchPoints
+		t.CaptainRegret += week.CaptainRegret
+		t.AutoSubPoints += week.AutoSubPoints
+		t.TransferCost += week.TransferCost
+	}
+
+	out := cmd.OutOrStdout()
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, w := range report.Gameweeks {
+			if err := enc.Encode(hindsightWeekLine{hindsightWeekVersion, w}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return writeCSV(out, hindsightColumns, report.Gameweeks)
+	}
+	printHindsight(out, report)
+	return nil
+}
+
+// reviewGameweek works out the best XI and captain from one gameweek's
+// squad using each player's live points.
+func reviewGameweek(ix *fpl.PlayerIndex, ev fpl.EntryEvent, picks *fpl.EntryPicks, stats map[int]fpl.LiveStats) (hindsightWeek, error) {
+	week := hindsightWeek{
+		Gameweek:     ev.Event,
+		Chip:         picks.ActiveChip,
+		Points:       ev.Points,
+		BenchPoints:  ev.PointsOnBench,
+		TransferCost: ev.EventTransfersCost,
+		BestXI:       []hindsightPlayer{},
+		AutoSubs:     []hindsightSub{},
+	}
+	player := func(id int) hindsightPlayer {
+		p := hindsightPlayer{ID: id, Name: fmt.Sprintf("#%d", id), Points: stats[id].TotalPoints}
+		if el := ix.Player(id); el != nil {
+			p.Name = el.WebName
+			if pos := ix.Position(el.ElementType); pos != nil {
+				p.Position = pos.SingularNameShort
+			}
+		}
+		return p
+	}
+
+	squad := make([]optimize.Candidate, 0, len(picks.Picks))
+	played := make([]fpl.SquadPlayer, 0, len(picks.Picks))
+	for _, p := range picks.Picks {
+		el := ix.Player(p.Element)
+		if el == nil {
+			return week, fmt.Errorf("player %d from gameweek %d is missing from bootstrap data", p.Element, ev.Event)
+		}
+		squad = append(squad, optimize.Candidate{ID: el.ID, Position: el.ElementType, Score: float64(stats[el.ID].TotalPoints)})
+		status := fpl.DidNotPlay
+		if stats[el.ID].Minutes > 0 {
+			status = fpl.Played
+		}
+		played = append(played, fpl.SquadPlayer{Pick: p, ElementType: el.ElementType, Status: status})
+	}
+	lineup, ok := optimize.BestLineup(squad, optimize.DefaultRules())
+	if !ok {
+		return week, fmt.Errorf("gameweek %d squad cannot field a legal XI", ev.Event)
+	}
+	counted := lineup.Starters
+	if picks.ActiveChip == fpl.ChipBenchBoost {
+		counted = squad
+	}
+	week.BestFormation = lineup.Formation.String()
+	for _, c := range lineup.Starters {
+		week.BestXI = append(week.BestXI, player(c.ID))
+	}
+
+	extra := 1
+	if picks.ActiveChip == fpl.ChipTripleCaptain {
+		extra = 2
+	}
+	best := 0
+	for _, c := range counted {
+		p := player(c.ID)
+		best += p.Points
+		if p.Points > week.BestCaptain.Points || week.BestCaptain.ID == 0 {
+			week.BestCaptain = p
+		}
+	}
+	week.Best = best + extra*week.BestCaptain.Points
+
+	captainPoints := 0
+	if armband := fpl.ApplyAutoSubs(played, picks.ActiveChip).Captain; armband != 0 {
+		p := player(armband)
+		week.Captain = &p
+		captainPoints = p.Points
+	}
+	week.CaptainRegret = extra * (week.BestCaptain.Points - captainPoints)
+
+	for _, sub := range picks.AutomaticSubs {
+		s := hindsightSub{Out: player(sub.ElementOut), In: player(sub.ElementIn)}
+		week.AutoSubs = append(week.AutoSubs, s)
+		week.AutoSubPoints += s.In.Points
+	}
+	week.Missed = max(0, week.Best-week.Points)
+	return week, nil
+}
+
+var chipNames = map[string]string{
+	fpl.ChipBenchBoost:    "BB",
+	fpl.ChipTripleCaptain: "TC",
+	fpl.ChipFreeHit:       "FH",
+	fpl.ChipWildcard:      "WC",
+}
+
+func chipLabel(chip string) string {
+	if label, ok := chipNames[chip]; ok {
+		return label
+	}
+	return chip
+}
+
+func playerPoints(p hindsightPlayer) string {
+	return fmt.Sprintf("%s %d", p.Name, p.Points)
+}
+
+func autoSubText(w hindsightWeek) string {
+	if len(w.AutoSubs) == 0 {
+		return ""
+	}
+	parts := make([]string, len(w.AutoSubs))
+	for i, s := range w.AutoSubs {
+		parts[i] = fmt.Sprintf("%s → %s", s.Out.Name, s.In.Name)
+	}
+	return fmt.Sprintf("%s (+%d)", strings.Join(parts, ", "), w.AutoSubPoints)
+}
+
+var hindsightColumns = []tableColumn[hindsightWeek]{
+	intColumn("gameweek", "GW", 0, func(w hindsightWeek) int { return w.Gameweek }),
+	{Key: "chip", Header: "Chip", Priority: 2, Value: func(w hindsightWeek) string { return chipLabel(w.Chip) }},
+	intColumn("points", "Pts", 0, func(w hindsightWeek) int { return w.Points }),
+	intColumn("best", "Best", 0, func(w hindsightWeek) int { return w.Best }),
+	intColumn("missed", "Missed", 1, func(w hindsightWeek) int { return w.Missed }),
+	intColumn("bench_points", "Bench", 1, func(w hindsightWeek) int { return w.BenchPoints }),
+	{Key: "best_formation", Header: "Best XI", Priority: 4, Value: func(w hindsightWeek) string { return w.BestFormation }},
+	{Key: "captain", Header: "Captain", Priority: 2, Value: func(w hindsightWeek) string {
+		if w.Captain == nil {
+			return "none played"
+		}
+		return playerPoints(*w.Captain)
+	}},
+	{Key: "best_captain", Header: "Best captain", Priority: 3, Value: func(w hindsightWeek) string { return playerPoints(w.BestCaptain) }},
+	intColumn("captain_regret", "Regret", 1, func(w hindsightWeek) int { return w.CaptainRegret }),
+	{Key: "auto_subs", Header: "Auto-subs", Priority: 3, Value: autoSubText},
+}
+
+func printHindsight(out io.Writer, report hindsightReport) {
+	colors := newPalette(out, rootOpts.color)
+	weeks := make([]int, len(report.Gameweeks))
+	for i, w := range report.Gameweeks {
+		weeks[i] = w.Gameweek
+	}
+	fmt.Fprintf(out, "%s | %s | GW %s\n\n", colors.bold("Hindsight"), report.EntryName, formatGWList(weeks))
+	visible := fitColumns(hindsightColumns, report.Gameweeks, terminalWidth(out))
+	renderTable(out, visible, report.Gameweeks, colors)
+
+	t := report.Totals
+	fmt.Fprintf(out, "\nScored %d of a possible %d (%d missed). %d points left on the bench, captain regret %d",
+		t.Points, t.Best, t.Missed, t.BenchPoints, t.CaptainRegret)
+	if t.AutoSubPoints > 0 {
+		fmt.Fprintf(out, ", auto-subs added %d", t.AutoSubPoints)
+	}
+	fmt.Fprintln(out, ".")
+	fmt.Fprintln(out, colors.dim("Points are before transfer hits; Best uses the best XI and captain from the same squad."))
+}
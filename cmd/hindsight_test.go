@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+// hindsightSquad is testSquad with the given points and chip.
+func hindsightSquad(types, points []int, captain, vice int, chip string) (*fpl.PlayerIndex, *fpl.EntryPicks, map[int]fpl.LiveStats) {
+	b, picks, stats := testSquad(types, captain, vice)
+	picks.ActiveChip = chip
+	for i, p := range points {
+		stats[i+1] = fpl.LiveStats{Minutes: 90, TotalPoints: p}
+	}
+	return fpl.NewPlayerIndex(b), picks, stats
+}
+
+func TestReviewGameweek(t *testing.T) {
+	// The best XI is the 6-point keeper, three defenders on 2, forward 9's
+	// 10, midfielders 13 and 5, forward 15 and three more players on 2:
+	// 44 points. The whole squad scores 50.
+	points := []int{6, 2, 2, 2, 5, 2, 2, 2, 10, 2, 2, 1, 8, 1, 3}
+	ev := fpl.EntryEvent{Event: 5, Points: 50}
+
+	ix, picks, stats := hindsightSquad(squadTypes, points, 9, 5, "")
+	week, err := reviewGameweek(ix, ev, picks, stats)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if week.Best != 54 || week.Missed != 4 || week.BestFormation != "3-4-3" {
+		t.Fatalf("expected a best of 54 in a 3-4-3, 4 missed, got %+v", week)
+	}
+	if week.BestCaptain.ID != 9 || week.Captain == nil || week.Captain.ID != 9 || week.CaptainRegret != 0 {
+		t.Fatalf("expected no regret for captaining forward 9, got %+v", week)
+	}
+
+	// Bench Boost counts every player in the best total.
+	ix, picks, stats = hindsightSquad(squadTypes, points, 9, 5, fpl.ChipBenchBoost)
+	if week, err = reviewGameweek(ix, ev, picks, stats); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if week.Best != 60 || week.Chip != fpl.ChipBenchBoost {
+		t.Fatalf("expected a Bench Boost best of 60, got %+v", week)
+	}
+
+	// Triple Captain triples both the best captain and the regret.
+	ix, picks, stats = hindsightSquad(squadTypes, points, 5, 9, fpl.ChipTripleCaptain)
+	if week, err = reviewGameweek(ix, ev, picks, stats); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if week.Best != 64 || week.Captain.ID != 5 || week.CaptainRegret != 10 {
+		t.Fatalf("expected a best of 64 and 10 regret for captaining midfielder 5, got %+v", week)
+	}
+
+	// A captain who did not play hands the armband to the vice-captain.
+	ix, picks, stats = hindsightSquad(squadTypes, points, 9, 5, "")
+	stats[9] = fpl.LiveStats{}
+	if week, err = reviewGameweek(ix, ev, picks, stats); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if week.Captain == nil || week.Captain.ID != 5 || week.BestCaptain.ID != 13 || week.CaptainRegret != 3 {
+		t.Fatalf("expected the vice-captain to take the armband with 3 regret, got %+v", week)
+	}
+
+	// A squad without a goalkeeper cannot field a legal XI.
+	noKeeper := []int{2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 2, 3, 2, 4}
+	ix, picks, stats = hindsightSquad(noKeeper, points, 9, 5, "")
+	if _, err := reviewGameweek(ix, ev, picks, stats); err == nil {
+		t.Fatal("expected an error for a squad that cannot field a legal XI")
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/projection"
+)
+
+// horizon is a run of upcoming gameweeks and the fixtures in them.
+type horizon struct {
+	Gameweeks []int
+	// Next is the next gameweek, the only one injury flags apply to.
+	Next     int
+	Schedule *projection.Schedule
+}
+
+// count returns how many fixtures team plays in gw.
+func (h *horizon) count(team, gw int) int {
+	return h.Schedule.Count(team, gw)
+}
+
+// gameweeks returns the horizon's gameweeks, or none for a nil horizon.
+func (h *horizon) gameweeks() []int {
+	if h == nil {
+		return []int{}
+	}
+	return h.Gameweeks
+}
+
+// nextGameweek returns the first gameweek whose deadline has not passed.
+func nextGameweek(events []fpl.Event) (int, error) {
+	for _, ev := range events {
+		if ev.IsNext {
+			return ev.ID, nil
+		}
+	}
+	for _, ev := range events {
+		if !ev.Finished && !ev.IsCurrent {
+			return ev.ID, nil
+		}
+	}
+	return 0, errors.New("no upcoming gameweek: the season has finished")
+}
+
+// currentGameweek returns the gameweek in progress, or the latest one whose
+// deadline has passed.
+func currentGameweek(events []fpl.Event) (int, error) {
+	for _, ev := range events {
+		if ev.IsCurrent {
+			return ev.ID, nil
+		}
+	}
+	return 0, errors.New("no current gameweek: the season has not started")
+}
+
+// resolveGameweek reads a single --gw value: "current" or a gameweek number.
+func resolveGameweek(events []fpl.Event, value string) (int, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" || value == "current" {
+		return currentGameweek(events)
+	}
+	gw, err := parseGW(value)
+	if err != nil {
+		return 0, err
+	}
+	if gw > len(events) {
+		return 0, fmt.Errorf("gameweek %d is outside the %d-gameweek season", gw, len(events))
+	}
+	return gw, nil
+}
+
+// loadHorizon loads the fixtures for `length` gameweeks from the next one.
+// One fixture list request covers every team, and the projection model needs
+// its finished results as well.
+func loadHorizon(ctx context.Context, client *fpl.Client, ix *fpl.PlayerIndex, length int) (*horizon, error) {
+	if length < 1 {
+		return nil, fmt.Errorf("horizon must be at least 1 gameweek, got %d", length)
+	}
+	events := ix.Bootstrap().Events
+	next, err := nextGameweek(events)
+	if err != nil {
+		return nil, err
+	}
+	var weeks []int
+	for gw := next; gw < next+length && gw <= len(events); gw++ {
+		weeks = append(weeks, gw)
+	}
+	return loadGameweeks(ctx, client, ix, weeks, next)
+}
+
+// loadGameweeks loads the fixtures for the given gameweeks.
+func loadGameweeks(ctx context.Context, client *fpl.Client, ix *fpl.PlayerIndex, weeks []int, next int) (*horizon, error) {
+	events := ix.Bootstrap().Events
+	for _, gw := range weeks {
+		if gw > len(events) {
+			return nil, fmt.Errorf("gameweek %d is outside the %d-gameweek season", gw, len(events))
+		}
+	}
+	if len(weeks) == 0 {
+		return nil, errors.New("no gameweeks left in the season")
+	}
+	fixtures, err := client.Fixtures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &horizon{Gameweeks: weeks, Next: next, Schedule: projection.NewSchedule(fixtures)}, nil
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestLoadHorizonReadsTheFixtureList(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path != "/fixtures/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"id":1,"event":5,"team_h":1,"team_a":2},{"id":2,"event":5,"team_h":3,"team_a":1},{"id":3,"event":6,"team_h":2,"team_a":3}]`))
+	}))
+	defer srv.Close()
+	client := fpl.NewClient(srv.Client(), 0)
+	client.SetBaseURL(srv.URL)
+
+	ix := fpl.NewPlayerIndex(&fpl.BootstrapStatic{
+		Events:   []fpl.Event{{ID: 1, Finished: true}, {ID: 2, Finished: true}, {ID: 3, Finished: true}, {ID: 4, Finished: true}, {ID: 5, IsNext: true}, {ID: 6}},
+		Teams:    []fpl.Team{{ID: 1}, {ID: 2}, {ID: 3}},
+		Elements: []fpl.Element{{ID: 10, Team: 1}, {ID: 20, Team: 2}, {ID: 30, Team: 3}},
+	})
+	h, err := loadHorizon(context.Background(), client, ix, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(h.Gameweeks) != 2 || h.Next != 5 {
+		t.Fatalf("expected gameweeks 5 and 6 from the next one, got %+v", h)
+	}
+	if h.count(1, 5) != 2 || h.count(1, 6) != 0 {
+		t.Fatalf("expected team 1 to double in GW5 and blank in GW6")
+	}
+	// One fixture list replaces an element summary per team.
+	if len(paths) != 1 {
+		t.Fatalf("expected a single /fixtures/ request, got %v", paths)
+	}
+}
@@ -0,0 +1,494 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/spf13/cobra"
+)
+
+// leagueEOReportVersion is the schema_version of leagueEOReport JSON output.
+const leagueEOReportVersion = 1
+
+// leaguePickVersion is the schema_version of each fpl league eo NDJSON line.
+const leaguePickVersion = 1
+
+// maxLeagueManagers caps how many managers' picks fpl league eo fetches
+// unless --top says otherwise: public leagues run to millions.
+const maxLeagueManagers = 500
+
+type leagueEOOptions struct {
+	id     int
+	gw     string
+	entry  int
+	rivals []int
+	top    int
+	limit  int
+}
+
+type leagueEOReport struct {
+	SchemaVersion int    `json:"schema_version"`
+	League        int    `json:"league"`
+	LeagueName    string `json:"league_name"`
+	Gameweek      int    `json:"gameweek"`
+	// Managers counts the managers whose picks were read.
+	Managers int `json:"managers"`
+	// Entry is the manager the rivals are compared with, when known.
+	Entry   int           `json:"entry,omitempty"`
+	Players []leaguePick  `json:"players"`
+	Rivals  []leagueRival `json:"rivals"`
+}
+
+// leaguePick is how a league picked one player. Ownership, Captaincy and
+// EO are fractions of its managers: EO sums the multipliers the player was
+// picked with, so a player everyone captains has an EO of 2.
+type leaguePick struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Team      string  `json:"team"`
+	Position  string  `json:"position"`
+	Owners    int     `json:"owners"`
+	Ownership float64 `json:"ownership"`
+	Captains  int     `json:"captains"`
+	Captaincy float64 `json:"captaincy"`
+	EO        float64 `json:"eo"`
+	// Mine is how the compared entry picked the player: bench, XI, C or
+	// TC, or empty when they do not own them.
+	Mine string `json:"mine,omitempty"`
+}
+
+// leaguePickLine is one player as streamed with --output ndjson.
+type leaguePickLine struct {
+	SchemaVersion int `json:"schema_version"`
+	leaguePick
+}
+
+// leagueRival is one rival's differentials: players they count more times
+// than the compared entry does, and the other way round.
+type leagueRival struct {
+	Rank   int                  `json:"rank"`
+	Entry  int                  `json:"entry"`
+	Name   string               `json:"name"`
+	Player string               `json:"player_name"`
+	Total  int                  `json:"total"`
+	Theirs []leagueDifferential `json:"theirs"`
+	Mine   []leagueDifferential `json:"mine"`
+}
+
+// leagueDifferential is a player one side counts Margin more times than
+// the other, e.g. 1 for a starter the other side lacks or for a captain
+// the other side only starts.
+type leagueDifferential struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Captain bool   `json:"is_captain"`
+	Margin  int    `json:"margin"`
+}
+
+func newLeagueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "league",
+		Short: "Analyse a classic mini-league",
+	}
+	cmd.AddCommand(newLeagueEOCmd())
+	return cmd
+}
+
+func newLeagueEOCmd() *cobra.Command {
+	opts := &leagueEOOptions{}
+	cmd := &cobra.Command{
+		Use:   "eo",
+		Short: "Show ownership, captaincy and effective ownership within a league",
+		Long: `Read every league manager's picks for a gameweek and show, per player, how many
+own them, how many captain them and their effective ownership (EO): the
+average multiplier they were picked with, so captaincy counts twice and a
+Triple Captain three times.
+
+Each rival is then compared with your squad (--entry, or the configured
+entry): the players they count more than you, which gain on you when they
+score, and the ones you count more than them. Rivals are the managers given
+with --rival, or the configured rivals, or else everyone else in the league.
+Picks are as made at the deadline, before automatic substitutions.
+
+--id defaults to the first configured league. Leagues of more than 500
+managers need --top to read only the top of the table.`,
+		Example: `  fpl league eo --id 314 --gw 12
+  fpl league eo --id 314 --entry 123456 --limit 30
+  fpl league eo --id 314 --rival 654321 --rival 111111
+  fpl league eo --id 314 --top 100 --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLeagueEO(cmd.Context(), cmd, opts)
+		},
+	}
+	cmd.Flags().IntVar(&opts.id, "id", 0, "classic league ID (defaults to the first configured league)")
+	cmd.Flags().StringVar(&opts.gw, "gw", "current", "gameweek: current or a number")
+	addEntryFlag(cmd, &opts.entry)
+	cmd.Flags().IntSliceVar(&opts.rivals, "rival", nil, "entry ID of a rival to compare with (repeatable; defaults to the configured rivals, else the whole league)")
+	cmd.Flags().IntVar(&opts.top, "top", 0, "only read the top N managers in the standings (0 for all)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "number of players to show, by EO (0 for all)")
+	cmd.RegisterFlagCompletionFunc("gw", completeGameweeks)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newLeagueCmd())
+	registerReportSchema(reportSchema{
+		Name:        "league-eo",
+		Version:     leagueEOReportVersion,
+		Description: "Ownership, captaincy and EO within a league, with rival differentials, from fpl league eo --json",
+		Sample:      leagueEOReport{},
+	})
+	registerReportSchema(reportSchema{
+		Name:        "league-eo-player",
+		Version:     leaguePickVersion,
+		Description: "One player's ownership, captaincy and EO within a league per line from fpl league eo --output ndjson",
+		Sample:      leaguePickLine{},
+	})
+}
+
+// resolveLeague returns the --id flag, falling back to the first league
+// from the environment, active profile or config file.
+func resolveLeague(cmd *cobra.Command, id int) (int, error) {
+	if !cmd.Flags().Changed("id") && len(rootOpts.leagues) > 0 {
+		id = rootOpts.leagues[0]
+	}
+	if id <= 0 {
+		return 0, errors.New("no league: pass --id or save one with fpl config set leagues <id>")
+	}
+	return id, nil
+}
+
+// resolveRivals returns the --rival flag, falling back to the rivals from
+// the environment, active profile or config file.
+func resolveRivals(cmd *cobra.Command, rivals []int) []int {
+	if !cmd.Flags().Changed("rival") {
+		return rootOpts.rivals
+	}
+	return rivals
+}
+
+// entryPicksResult keeps a failed manager from sinking the whole league.
+type entryPicksResult struct {
+	picks *fpl.EntryPicks
+	err   error
+}
+
+func runLeagueEO(ctx context.Context, cmd *cobra.Command, opts *leagueEOOptions) error {
+	if opts.top < 0 || opts.limit < 0 {
+		return errors.New("--top and --limit must not be negative")
+	}
+	leagueID, err := resolveLeague(cmd, opts.id)
+	if err != nil {
+		return err
+	}
+	// The comparison is optional: without an entry only the EO is shown.
+	entryID, _ := resolveEntry(cmd, opts.entry)
+
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+	gw, err := resolveGameweek(bootstrap.Events, opts.gw)
+	if err != nil {
+		return err
+	}
+	league, standings, err := loadStandings(ctx, client, leagueID, opts.top)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]int, 0, len(standings)+1)
+	for _, s := range standings {
+		entries = append(entries, s.Entry)
+	}
+	if entryID > 0 && !containsInt(entries, entryID) {
+		entries = append(entries, entryID)
+	}
+	results, _ := fetchAll(ctx, entries, func(ctx context.Context, id int) (entryPicksResult, error) {
+		picks, err := client.EntryPicks(ctx, id, gw)
+		return entryPicksResult{picks, err}, nil
+	})
+	picks := make(map[int]*fpl.EntryPicks, len(results))
+	for _, id := range entries {
+		res := results[id]
+		if res.err != nil {
+			if id == entryID {
+				return fmt.Errorf("entry %d: %w", id, res.err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: entry %d: %v\n", id, res.err)
+			continue
+		}
+		picks[id] = res.picks
+	}
+
+	rivals := resolveRivals(cmd, opts.rivals)
+	for _, id := range rivals {
+		if !containsInt(entries[:len(standings)], id) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: rival %d is not in the standings read; skipping\n", id)
+		}
+	}
+
+	report := buildLeagueEO(ix, standings, picks, entryID, rivals)
+	report.League, report.LeagueName, report.Gameweek = league.ID, league.Name, gw
+
+	out := cmd.OutOrStdout()
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, p := range limitPicks(report.Players, opts.limit) {
+			if err := enc.Encode(leaguePickLine{leaguePickVersion, p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return writeCSV(out, leagueEOColumns(report), limitPicks(report.Players, opts.limit))
+	}
+	printLeagueEO(out, report, opts.limit)
+	return nil
+}
+
+// loadStandings pages through a league's standings, stopping after top
+// managers when top is positive.
+func loadStandings(ctx context.Context, client *fpl.Client, id, top int) (fpl.League, []fpl.Standing, error) {
+	var (
+		league    fpl.League
+		standings []fpl.Standing
+	)
+	for page := 1; ; page++ {
+		resp, err := client.LeagueStandings(ctx, id, page)
+		if err != nil {
+			return league, nil, err
+		}
+		league = resp.League
+		standings = append(standings, resp.Standings.Results...)
+		if top > 0 && len(standings) >= top {
+			return league, standings[:top], nil
+		}
+		if top == 0 && len(standings) > maxLeagueManagers {
+			return league, nil, fmt.Errorf("league %d has more than %d managers; pass --top to read only the top of the table", id, maxLeagueManagers)
+		}
+		if !resp.Standings.HasNext || len(resp.Standings.Results) == 0 {
+			return league, standings, nil
+		}
+	}
+}
+
+// buildLeagueEO tallies the picks of the league's managers and compares
+// each rival with entry. Rivals are the given managers, or every other one
+// when none are given. Managers without picks are left out; entry counts
+// towards the league only if it is in the standings.
+func buildLeagueEO(ix *fpl.PlayerIndex, standings []fpl.Standing, picks map[int]*fpl.EntryPicks, entry int, rivals []int) leagueEOReport {
+	report := leagueEOReport{
+		SchemaVersion: leagueEOReportVersion,
+		Players:       []leaguePick{},
+		Rivals:        []leagueRival{},
+	}
+	mine := multipliers(picks[entry])
+	if picks[entry] != nil {
+		report.Entry = entry
+	}
+
+	tally := make(map[int]*leaguePick)
+	for _, s := range standings {
+		p := picks[s.Entry]
+		if p == nil {
+			continue
+		}
+		report.Managers++
+		for _, pick := range p.Picks {
+			t := tally[pick.Element]
+			if t == nil {
+				t = &leaguePick{ID: pick.Element, Name: "Unknown"}
+				if m, ok := mine[pick.Element]; ok {
+					t.Mine = multiplierLabel(m)
+				}
+				if el := ix.Player(pick.Element); el != nil {
+					t.Name, t.Team = el.WebName, teamShortName(ix, el.Team)
+					if pos := ix.Position(el.ElementType); pos != nil {
+						t.Position = pos.SingularNameShort
+					}
+				}
+				tally[pick.Element] = t
+			}
+			t.Owners++
+			if pick.IsCaptain {
+				t.Captains++
+			}
+			t.EO += float64(pick.Multiplier)
+		}
+
+		if report.Entry == 0 || s.Entry == entry {
+			continue
+		}
+		if len(rivals) > 0 && !containsInt(rivals, s.Entry) {
+			continue
+		}
+		theirs := multipliers(p)
+		rival := leagueRival{
+			Rank:   s.Rank,
+			Entry:  s.Entry,
+			Name:   s.EntryName,
+			Player: s.PlayerName,
+			Total:  s.Total,
+			Theirs: differentials(ix, theirs, mine, p),
+			Mine:   differentials(ix, mine, theirs, picks[entry]),
+		}
+		report.Rivals = append(report.Rivals, rival)
+	}
+
+	if report.Managers > 0 {
+		n := float64(report.Managers)
+		for _, t := range tally {
+			t.Ownership = roundTo(float64(t.Owners)/n, 4)
+			t.Captaincy = roundTo(float64(t.Captains)/n, 4)
+			t.EO = roundTo(t.EO/n, 4)
+			report.Players = append(report.Players, *t)
+		}
+	}
+	sort.Slice(report.Players, func(i, j int) bool {
+		a, b := report.Players[i], report.Players[j]
+		if a.EO != b.EO {
+			return a.EO > b.EO
+		}
+		if a.Owners != b.Owners {
+			return a.Owners > b.Owners
+		}
+		return a.ID < b.ID
+	})
+	return report
+}
+
+func multipliers(picks *fpl.EntryPicks) map[int]int {
+	m := make(map[int]int)
+	if picks == nil {
+		return m
+	}
+	for _, p := range picks.Picks {
+		m[p.Element] = p.Multiplier
+	}
+	return m
+}
+
+// differentials lists the players side counts more times than other,
+// biggest margin first.
+func differentials(ix *fpl.PlayerIndex, side, other map[int]int, picks *fpl.EntryPicks) []leagueDifferential {
+	diffs := []leagueDifferential{}
+	for _, p := range picks.Picks {
+		margin := side[p.Element] - other[p.Element]
+		if margin <= 0 {
+			continue
+		}
+		d := leagueDifferential{ID: p.Element, Name: "Unknown", Captain: p.IsCaptain, Margin: margin}
+		if el := ix.Player(p.Element); el != nil {
+			d.Name = el.WebName
+		}
+		diffs = append(diffs, d)
+	}
+	sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].Margin > diffs[j].Margin })
+	return diffs
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func limitPicks(players []leaguePick, limit int) []leaguePick {
+	if limit > 0 && len(players) > limit {
+		return players[:limit]
+	}
+	return players
+}
+
+func multiplierLabel(m int) string {
+	switch m {
+	case 0:
+		return "bench"
+	case 1:
+		return "XI"
+	case 2:
+		return "C"
+	}
+	return "TC"
+}
+
+var leaguePickColumns = []tableColumn[leaguePick]{
+	{Key: "name", Header: "Player", Value: func(p leaguePick) string { return p.Name }},
+	{Key: "team", Header: "Team", Priority: 2, Value: func(p leaguePick) string { return p.Team }},
+	{Key: "position", Header: "Pos", Priority: 3, Value: func(p leaguePick) string { return p.Position }},
+	{Key: "ownership", Header: "Own", Priority: 1, AlignRight: true, Value: func(p leaguePick) string { return percent(p.Ownership) }, Number: func(p leaguePick) float64 { return p.Ownership }},
+	{Key: "captaincy", Header: "Capt", Priority: 1, AlignRight: true, Value: func(p leaguePick) string { return percent(p.Captaincy) }, Number: func(p leaguePick) float64 { return p.Captaincy }},
+	{Key: "eo", Header: "EO", AlignRight: true, Value: func(p leaguePick) string { return percent(p.EO) }, Number: func(p leaguePick) float64 { return p.EO }},
+}
+
+// leagueEOColumns adds how the compared entry picked each player, when
+// there is one.
+func leagueEOColumns(report leagueEOReport) []tableColumn[leaguePick] {
+	if report.Entry == 0 {
+		return leaguePickColumns
+	}
+	mine := tableColumn[leaguePick]{Key: "mine", Header: "Mine", Priority: 2, Value: func(p leaguePick) string { return p.Mine }}
+	return append(append([]tableColumn[leaguePick](nil), leaguePickColumns...), mine)
+}
+
+func differentialList(diffs []leagueDifferential) string {
+	if len(diffs) == 0 {
+		return "-"
+	}
+	names := make([]string, len(diffs))
+	for i, d := range diffs {
+		names[i] = d.Name
+		if d.Captain {
+			names[i] += " (C)"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+var leagueRivalColumns = []tableColumn[leagueRival]{
+	intColumn("rank", "#", 0, func(r leagueRival) int { return r.Rank }),
+	{Key: "name", Header: "Team", Value: func(r leagueRival) string { return r.Name }},
+	intColumn("total", "Total", 2, func(r leagueRival) int { return r.Total }),
+	{Key: "theirs", Header: "Theirs", Value: func(r leagueRival) string { return differentialList(r.Theirs) }},
+	{Key: "mine", Header: "Mine", Priority: 1, Value: func(r leagueRival) string { return differentialList(r.Mine) }},
+}
+
+func printLeagueEO(out io.Writer, report leagueEOReport, limit int) {
+	colors := newPalette(out, rootOpts.color)
+	width := terminalWidth(out)
+	fmt.Fprintf(out, "%s | GW %d | %d managers\n\n", colors.bold(report.LeagueName), report.Gameweek, report.Managers)
+
+	players := limitPicks(report.Players, limit)
+	renderTable(out, fitColumns(leagueEOColumns(report), players, width), players, colors)
+	if len(players) < len(report.Players) {
+		fmt.Fprintln(out, colors.dim(fmt.Sprintf("(%d more; use --limit 0 for all)", len(report.Players)-len(players))))
+	}
+
+	if report.Entry == 0 {
+		fmt.Fprintln(out, colors.dim("\nPass --entry or configure one to compare rivals with your squad."))
+		return
+	}
+	if len(report.Rivals) > 0 {
+		fmt.Fprintln(out, colors.bold("\nDifferentials vs your squad"))
+		renderTable(out, fitColumns(leagueRivalColumns, report.Rivals, width), report.Rivals, colors)
+		fmt.Fprintln(out, colors.dim("Theirs gain on you when they score and mine gain on them; (C) marks a captain."))
+	}
+}
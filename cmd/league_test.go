@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestBuildLeagueEO(t *testing.T) {
+	b := &fpl.BootstrapStatic{}
+	for id := 1; id <= 4; id++ {
+		b.Elements = append(b.Elements, fpl.Element{ID: id, WebName: string(rune('A' + id - 1))})
+	}
+	pick := func(element, multiplier int) fpl.Pick {
+		return fpl.Pick{Element: element, Multiplier: multiplier, IsCaptain: multiplier > 1}
+	}
+	standings := []fpl.Standing{{Entry: 10, Rank: 1}, {Entry: 20, Rank: 2}, {Entry: 30, Rank: 3}}
+	picks := map[int]*fpl.EntryPicks{
+		// Me: A captained, B starting, C on the bench.
+		10: {Picks: []fpl.Pick{pick(1, 2), pick(2, 1), pick(3, 0)}},
+		// A rival who triple captains B and starts D.
+		20: {Picks: []fpl.Pick{pick(2, 3), pick(1, 1), pick(4, 1)}},
+		// Entry 30 has no picks, so only two managers count.
+	}
+
+	report := buildLeagueEO(fpl.NewPlayerIndex(b), standings, picks, 10, nil)
+	if report.Managers != 2 || report.Entry != 10 {
+		t.Fatalf("expected 2 managers compared with entry 10, got %d and %d", report.Managers, report.Entry)
+	}
+	want := map[int]leaguePick{
+		1: {Owners: 2, Ownership: 1, Captains: 1, Captaincy: 0.5, EO: 1.5, Mine: "C"},
+		2: {Owners: 2, Ownership: 1, Captains: 1, Captaincy: 0.5, EO: 2, Mine: "XI"},
+		3: {Owners: 1, Ownership: 0.5, EO: 0, Mine: "bench"},
+		4: {Owners: 1, Ownership: 0.5, EO: 0.5},
+	}
+	if len(report.Players) != len(want) || report.Players[0].ID != 2 {
+		t.Fatalf("expected four players led by B, got %+v", report.Players)
+	}
+	for _, p := range report.Players {
+		w := want[p.ID]
+		if p.Owners != w.Owners || p.Ownership != w.Ownership || p.Captains != w.Captains || p.Captaincy != w.Captaincy || p.EO != w.EO || p.Mine != w.Mine {
+			t.Errorf("player %d: got %+v, want %+v", p.ID, p, w)
+		}
+	}
+
+	if len(report.Rivals) != 1 {
+		t.Fatalf("expected one rival, got %+v", report.Rivals)
+	}
+	rival := report.Rivals[0]
+	// B counts twice more for the rival, then D; A counts once more for me.
+	if len(rival.Theirs) != 2 || rival.Theirs[0].ID != 2 || rival.Theirs[0].Margin != 2 || rival.Theirs[1].ID != 4 {
+		t.Errorf("unexpected rival differentials: %+v", rival.Theirs)
+	}
+	if len(rival.Mine) != 1 || rival.Mine[0].ID != 1 || rival.Mine[0].Margin != 1 || !rival.Mine[0].Captain {
+		t.Errorf("unexpected differentials of mine: %+v", rival.Mine)
+	}
+
+	// Configured rivals narrow the comparison but not the league's EO.
+	report = buildLeagueEO(fpl.NewPlayerIndex(b), standings, picks, 10, []int{30})
+	if report.Managers != 2 || len(report.Rivals) != 0 {
+		t.Errorf("expected only entry 30 compared, which has no picks, got %d managers and rivals %+v", report.Managers, report.Rivals)
+	}
+	report = buildLeagueEO(fpl.NewPlayerIndex(b), standings, picks, 10, []int{20})
+	if len(report.Rivals) != 1 || report.Rivals[0].Entry != 20 {
+		t.Errorf("expected entry 20 as the only rival, got %+v", report.Rivals)
+	}
+}
@@ -0,0 +1,497 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/spf13/cobra"
+)
+
+// liveReportVersion is the schema_version of liveReport JSON output.
+const liveReportVersion = 1
+
+// minWatchInterval keeps --watch from polling the API harder than the live
+// data changes.
+const minWatchInterval = 10 * time.Second
+
+type liveOptions struct {
+	gw    string
+	watch time.Duration
+	limit int
+}
+
+type liveReport struct {
+	SchemaVersion int           `json:"schema_version"`
+	Gameweek      int           `json:"gameweek"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	Fixtures      []liveFixture `json:"fixtures"`
+	Events        []liveEvent   `json:"events"`
+	TopScorers    []liveScorer  `json:"top_scorers"`
+
+	// totals is every player's live points plus provisional bonus, so the
+	// next poll can tell who has moved even if they were not a top scorer.
+	totals map[int]int
+}
+
+type liveFixture struct {
+	ID        int        `json:"id"`
+	Home      string     `json:"home"`
+	Away      string     `json:"away"`
+	HomeScore int        `json:"home_score"`
+	AwayScore int        `json:"away_score"`
+	Minutes   int        `json:"minutes"`
+	Kickoff   *time.Time `json:"kickoff_time"`
+	// Status is "upcoming", "live", "full time" or "confirmed"; bonus is
+	// only added to player totals once a result is confirmed.
+	Status string `json:"status"`
+	// Changed marks a score or status that moved since the last poll.
+	Changed bool `json:"changed"`
+}
+
+// liveEvent is a player's goals, assists or own goals in one fixture.
+type liveEvent struct {
+	Fixture int `json:"fixture"`
+	// Kind is "goal", "own goal" or "assist".
+	Kind  string `json:"kind"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Team  string `json:"team"`
+	Count int    `json:"count"`
+	// New marks events since the last poll.
+	New bool `json:"new"`
+}
+
+type liveScorer struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Team             string `json:"team"`
+	Position         string `json:"position"`
+	Minutes          int    `json:"minutes"`
+	Points           int    `json:"points"`
+	BPS              int    `json:"bps"`
+	ProvisionalBonus int    `json:"provisional_bonus"`
+	// Total is Points plus ProvisionalBonus; Change is how far it moved
+	// since the last poll.
+	Total  int `json:"total"`
+	Change int `json:"change"`
+}
+
+// eventKinds maps fixture stat identifiers to event kinds, in display order.
+var eventKinds = []struct{ stat, kind string }{
+	{"goals_scored", "goal"},
+	{"own_goals", "own goal"},
+	{"assists", "assist"},
+}
+
+func newLiveCmd() *cobra.Command {
+	opts := &liveOptions{}
+	cmd := &cobra.Command{
+		Use:   "live",
+		Short: "Follow a gameweek's scores, goals and top scorers as they happen",
+		Long: `Show a gameweek as it stands: every fixture's score, goals and assists, and
+the top-scoring players with their BPS and provisional bonus.
+
+--watch polls again at the given interval and redraws the dashboard in place,
+highlighting scores, events and players that changed since the last poll. It
+stops by itself once every result is confirmed. With --output ndjson each poll
+is written as one report per line.`,
+		Example: `  fpl live
+  fpl live --watch 60s
+  fpl live --gw 7 --limit 20
+  fpl live --watch 2m --output ndjson`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLive(cmd.Context(), cmd, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.gw, "gw", "current", "gameweek: current or a number")
+	cmd.Flags().DurationVar(&opts.watch, "watch", 0, "poll again at this interval and redraw (e.g. 60s); 0 shows one snapshot")
+	cmd.Flags().IntVar(&opts.limit, "limit", 10, "number of top scorers to show (0 for all who have played)")
+	cmd.RegisterFlagCompletionFunc("gw", completeGameweeks)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newLiveCmd())
+	registerReportSchema(reportSchema{
+		Name:        "live",
+		Version:     liveReportVersion,
+		Description: "A gameweek's fixtures, goal and assist events and top scorers from fpl live --json",
+		Sample:      liveReport{},
+	})
+}
+
+func runLive(ctx context.Context, cmd *cobra.Command, opts *liveOptions) error {
+	if opts.limit < 0 {
+		return fmt.Errorf("--limit must not be negative, got %d", opts.limit)
+	}
+	format := outputFormat()
+	if opts.watch != 0 {
+		if opts.watch < minWatchInterval {
+			return fmt.Errorf("--watch must be at least %s, got %s", minWatchInterval, opts.watch)
+		}
+		if format != outputTable && format != outputNDJSON {
+			return errors.New("--watch redraws a table or streams ndjson; use --output table or ndjson")
+		}
+	}
+
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+	gw, err := resolveGameweek(bootstrap.Events, opts.gw)
+	if err != nil {
+		return err
+	}
+	poll := func(ctx context.Context) (liveReport, error) {
+		live, err := client.EventLive(ctx, gw)
+		if err != nil {
+			return liveReport{}, err
+		}
+		fixtures, err := client.Fixtures(ctx)
+		if err != nil {
+			return liveReport{}, err
+		}
+		report := buildLiveReport(ix, gw, live.Stats(), fixtures, opts.limit)
+		report.UpdatedAt = time.Now()
+		return report, nil
+	}
+
+	out := cmd.OutOrStdout()
+	if opts.watch == 0 {
+		report, err := poll(ctx)
+		if err != nil {
+			return err
+		}
+		return writeLive(out, format, report, 0)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(opts.watch)
+	defer ticker.Stop()
+	var prev *liveReport
+	for {
+		report, err := poll(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil && prev == nil:
+			return err
+		case err != nil:
+			// Keep the last dashboard up through a dropped connection.
+			fmt.Fprintf(cmd.ErrOrStderr(), "poll failed, retrying in %s: %v\n", opts.watch, err)
+		default:
+			markLiveChanges(prev, &report)
+			if format == outputTable && isTerminal(out) {
+				fmt.Fprint(out, "\x1b[H\x1b[2J")
+			} else if format == outputTable && prev != nil {
+				fmt.Fprintln(out)
+			}
+			if err := writeLive(out, format, report, opts.watch); err != nil {
+				return err
+			}
+			if allConfirmed(report.Fixtures) {
+				return nil
+			}
+			prev = &report
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeLive(out io.Writer, format string, report liveReport, watch time.Duration) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		return json.NewEncoder(out).Encode(report)
+	case outputCSV:
+		return writeCSV(out, liveScorerColumns, report.TopScorers)
+	}
+	printLive(out, report, watch)
+	return nil
+}
+
+// buildLiveReport summarises gameweek gw from live player stats and the
+// season's fixtures. limit caps the top scorers, 0 meaning everyone who has
+// played.
+func buildLiveReport(ix *fpl.PlayerIndex, gw int, stats map[int]fpl.LiveStats, fixtures []fpl.Fixture, limit int) liveReport {
+	report := liveReport{
+		SchemaVersion: liveReportVersion,
+		Gameweek:      gw,
+		Fixtures:      []liveFixture{},
+		Events:        []liveEvent{},
+		TopScorers:    []liveScorer{},
+		totals:        make(map[int]int),
+	}
+
+	var week []fpl.Fixture
+	for _, f := range fixtures {
+		if f.Event != nil && *f.Event == gw {
+			week = append(week, f)
+		}
+	}
+	sort.SliceStable(week, func(i, j int) bool {
+		a, b := week[i].KickoffTime, week[j].KickoffTime
+		if a != nil && b != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return week[i].ID < week[j].ID
+	})
+	for i := range week {
+		f := &week[i]
+		report.Fixtures = append(report.Fixtures, liveFixture{
+			ID:        f.ID,
+			Home:      teamShortName(ix, f.TeamH),
+			Away:      teamShortName(ix, f.TeamA),
+			HomeScore: scoreOf(f.TeamHScore),
+			AwayScore: scoreOf(f.TeamAScore),
+			Minutes:   f.Minutes,
+			Kickoff:   f.KickoffTime,
+			Status:    fixtureStatus(f),
+		})
+		for _, k := range eventKinds {
+			values := f.Stat(k.stat)
+			sort.SliceStable(values, func(i, j int) bool { return values[i].Value > values[j].Value })
+			for _, v := range values {
+				if v.Value <= 0 {
+					continue
+				}
+				event := liveEvent{Fixture: f.ID, Kind: k.kind, ID: v.Element, Name: "Unknown", Count: v.Value}
+				if el := ix.Player(v.Element); el != nil {
+					event.Name, event.Team = el.WebName, teamShortName(ix, el.Team)
+				}
+				report.Events = append(report.Events, event)
+			}
+		}
+	}
+
+	bonus := fpl.ProvisionalBonuses(fixtures, gw)
+	for id, s := range stats {
+		el := ix.Player(id)
+		if el == nil || (s.Minutes == 0 && s.TotalPoints == 0) {
+			continue
+		}
+		scorer := liveScorer{
+			ID:               id,
+			Name:             el.WebName,
+			Team:             teamShortName(ix, el.Team),
+			Minutes:          s.Minutes,
+			Points:           s.TotalPoints,
+			BPS:              s.BPS,
+			ProvisionalBonus: bonus[id],
+			Total:            s.TotalPoints + bonus[id],
+		}
+		if pos := ix.Position(el.ElementType); pos != nil {
+			scorer.Position = pos.SingularNameShort
+		}
+		report.totals[id] = scorer.Total
+		report.TopScorers = append(report.TopScorers, scorer)
+	}
+	sort.Slice(report.TopScorers, func(i, j int) bool {
+		a, b := report.TopScorers[i], report.TopScorers[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		if a.BPS != b.BPS {
+			return a.BPS > b.BPS
+		}
+		return a.ID < b.ID
+	})
+	if limit > 0 && len(report.TopScorers) > limit {
+		report.TopScorers = report.TopScorers[:limit]
+	}
+	return report
+}
+
+// markLiveChanges flags what moved in cur since prev, the previous poll.
+// Nothing is flagged on the first poll.
+func markLiveChanges(prev, cur *liveReport) {
+	if prev == nil {
+		return
+	}
+	fixtures := make(map[int]liveFixture, len(prev.Fixtures))
+	for _, f := range prev.Fixtures {
+		fixtures[f.ID] = f
+	}
+	for i, f := range cur.Fixtures {
+		was, ok := fixtures[f.ID]
+		cur.Fixtures[i].Changed = !ok || was.HomeScore != f.HomeScore || was.AwayScore != f.AwayScore || was.Status != f.Status
+	}
+
+	type eventKey struct {
+		fixture, id int
+		kind        string
+	}
+	counts := make(map[eventKey]int, len(prev.Events))
+	for _, e := range prev.Events {
+		counts[eventKey{e.Fixture, e.ID, e.Kind}] = e.Count
+	}
+	for i, e := range cur.Events {
+		cur.Events[i].New = e.Count > counts[eventKey{e.Fixture, e.ID, e.Kind}]
+	}
+
+	for i, s := range cur.TopScorers {
+		cur.TopScorers[i].Change = s.Total - prev.totals[s.ID]
+	}
+}
+
+func fixtureStatus(f *fpl.Fixture) string {
+	switch {
+	case f.BonusConfirmed():
+		return "confirmed"
+	case f.FinishedProvisional:
+		return "full time"
+	case f.Started:
+		return "live"
+	}
+	return "upcoming"
+}
+
+func allConfirmed(fixtures []liveFixture) bool {
+	for _, f := range fixtures {
+		if f.Status != "confirmed" {
+			return false
+		}
+	}
+	return true
+}
+
+func scoreOf(goals *int) int {
+	if goals == nil {
+		return 0
+	}
+	return *goals
+}
+
+// kickoffLabel shows a kickoff in the --timezone zone, or "TBC" when it has
+// not been scheduled.
+func kickoffLabel(t *time.Time) string {
+	if t == nil {
+		return "TBC"
+	}
+	loc, err := time.LoadLocation(rootOpts.timezone)
+	if err != nil {
+		loc = time.Local
+	}
+	return t.In(loc).Format("Mon 15:04")
+}
+
+var liveFixtureColumns = []tableColumn[liveFixture]{
+	{Key: "match", Header: "Match", Value: func(f liveFixture) string {
+		if f.Status == "upcoming" {
+			return fmt.Sprintf("%s v %s", f.Home, f.Away)
+		}
+		return fmt.Sprintf("%s %d-%d %s", f.Home, f.HomeScore, f.AwayScore, f.Away)
+	}},
+	{Key: "status", Header: "Status", Value: func(f liveFixture) string {
+		switch f.Status {
+		case "upcoming":
+			return kickoffLabel(f.Kickoff)
+		case "live":
+			return fmt.Sprintf("%d'", f.Minutes)
+		case "full time":
+			return "FT"
+		}
+		return "FT, confirmed"
+	}},
+}
+
+var liveEventColumns = []tableColumn[liveEvent]{
+	{Key: "kind", Header: "Event", Value: func(e liveEvent) string {
+		if e.Count > 1 {
+			return fmt.Sprintf("%s ×%d", e.Kind, e.Count)
+		}
+		return e.Kind
+	}},
+	{Key: "name", Header: "Player", Value: func(e liveEvent) string { return e.Name }},
+	{Key: "team", Header: "Team", Priority: 1, Value: func(e liveEvent) string { return e.Team }},
+}
+
+var liveScorerColumns = []tableColumn[liveScorer]{
+	{Key: "name", Header: "Player", Value: func(s liveScorer) string { return s.Name }},
+	{Key: "team", Header: "Team", Priority: 1, Value: func(s liveScorer) string { return s.Team }},
+	{Key: "position", Header: "Pos", Priority: 2, Value: func(s liveScorer) string { return s.Position }},
+	intColumn("minutes", "Min", 2, func(s liveScorer) int { return s.Minutes }),
+	intColumn("points", "Pts", 0, func(s liveScorer) int { return s.Points }),
+	intColumn("bps", "BPS", 1, func(s liveScorer) int { return s.BPS }),
+	{Key: "provisional_bonus", Header: "Bonus", Priority: 1, AlignRight: true, Value: func(s liveScorer) string { return provisionalText(s.ProvisionalBonus) }},
+	intColumn("total", "Total", 0, func(s liveScorer) int { return s.Total }),
+	{Key: "change", Header: "Δ", Priority: 3, AlignRight: true, Value: func(s liveScorer) string {
+		if s.Change == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%+d", s.Change)
+	}, Number: func(s liveScorer) float64 { return float64(s.Change) }},
+}
+
+func printLive(out io.Writer, report liveReport, watch time.Duration) {
+	colors := newPalette(out, rootOpts.color)
+	width := terminalWidth(out)
+	fmt.Fprintf(out, "%s | updated %s\n\n", colors.bold(fmt.Sprintf("GW %d live", report.Gameweek)), report.UpdatedAt.Format("15:04:05"))
+
+	fixtureColumns := highlightRows(liveFixtureColumns, func(f liveFixture) bool { return f.Changed })
+	renderTable(out, fitColumns(fixtureColumns, report.Fixtures, width), report.Fixtures, colors)
+
+	if len(report.Events) > 0 {
+		fmt.Fprintln(out)
+		matches := make(map[int]liveFixture, len(report.Fixtures))
+		for _, f := range report.Fixtures {
+			matches[f.ID] = f
+		}
+		eventColumns := append([]tableColumn[liveEvent]{{Key: "fixture", Header: "Match", Priority: 2, Value: func(e liveEvent) string {
+			f := matches[e.Fixture]
+			return fmt.Sprintf("%s v %s", f.Home, f.Away)
+		}}}, liveEventColumns...)
+		eventColumns = highlightRows(eventColumns, func(e liveEvent) bool { return e.New })
+		renderTable(out, fitColumns(eventColumns, report.Events, width), report.Events, colors)
+	}
+
+	fmt.Fprintln(out)
+	if len(report.TopScorers) == 0 {
+		fmt.Fprintln(out, "Nobody has played yet.")
+	} else {
+		scorerColumns := highlightRows(liveScorerColumns, func(s liveScorer) bool { return s.Change != 0 })
+		renderTable(out, fitColumns(scorerColumns, report.TopScorers, width), report.TopScorers, colors)
+	}
+	for _, s := range report.TopScorers {
+		if s.ProvisionalBonus > 0 {
+			fmt.Fprintln(out, colors.dim(provisionalNote))
+			break
+		}
+	}
+	if watch > 0 {
+		fmt.Fprintln(out, colors.dim(fmt.Sprintf("Refreshing every %s; highlighted rows changed since the last poll. Ctrl-C to stop.", watch)))
+	}
+}
+
+// highlightRows styles every cell of the rows changed reports.
+func highlightRows[R any](columns []tableColumn[R], changed func(R) bool) []tableColumn[R] {
+	styled := append([]tableColumn[R](nil), columns...)
+	for i := range styled {
+		styled[i].Style = func(p palette, row R, cell string) string {
+			if changed(row) {
+				return p.yellow(cell)
+			}
+			return cell
+		}
+	}
+	return styled
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestBuildLiveReport(t *testing.T) {
+	gw := 5
+	b := &fpl.BootstrapStatic{
+		Teams:        []fpl.Team{{ID: 1, ShortName: "ONE"}, {ID: 2, ShortName: "TWO"}, {ID: 3, ShortName: "THR"}, {ID: 4, ShortName: "FOU"}},
+		ElementTypes: []fpl.ElementType{{ID: 3, SingularNameShort: "MID"}, {ID: 4, SingularNameShort: "FWD"}},
+		Elements: []fpl.Element{
+			{ID: 1, WebName: "Striker", Team: 1, ElementType: 4},
+			{ID: 2, WebName: "Creator", Team: 1, ElementType: 3},
+			{ID: 3, WebName: "Keeper", Team: 2, ElementType: 3},
+			{ID: 4, WebName: "Benched", Team: 2, ElementType: 3},
+		},
+	}
+	home, away := 2, 0
+	fixtures := []fpl.Fixture{
+		{ID: 10, Event: &gw, TeamH: 3, TeamA: 4},
+		{ID: 11, Event: &gw, TeamH: 1, TeamA: 2, TeamHScore: &home, TeamAScore: &away, Started: true, Minutes: 70, Stats: []fpl.FixtureStat{
+			{Identifier: "goals_scored", Home: []fpl.StatValue{{Element: 1, Value: 2}}},
+			{Identifier: "assists", Home: []fpl.StatValue{{Element: 2, Value: 1}}},
+			{Identifier: "bps", Home: []fpl.StatValue{{Element: 1, Value: 40}, {Element: 2, Value: 20}}, Away: []fpl.StatValue{{Element: 3, Value: 25}}},
+		}},
+	}
+	stats := map[int]fpl.LiveStats{
+		1: {Minutes: 70, TotalPoints: 10, BPS: 40},
+		2: {Minutes: 70, TotalPoints: 5, BPS: 20},
+		3: {Minutes: 70, TotalPoints: 2, BPS: 25},
+		4: {},
+	}
+
+	report := buildLiveReport(fpl.NewPlayerIndex(b), gw, stats, fixtures, 2)
+	if len(report.Fixtures) != 2 || report.Fixtures[1].Status != "live" || report.Fixtures[1].HomeScore != 2 || report.Fixtures[0].Status != "upcoming" {
+		t.Fatalf("unexpected fixtures: %+v", report.Fixtures)
+	}
+	if len(report.Events) != 2 || report.Events[0].Kind != "goal" || report.Events[0].Count != 2 || report.Events[1].Kind != "assist" {
+		t.Fatalf("expected a brace and an assist, got %+v", report.Events)
+	}
+	// The striker's 10 plus 3 bonus, then the creator's 5 plus 1 ahead of
+	// the keeper's 2 plus 2 on BPS; the player yet to play is left out.
+	if len(report.TopScorers) != 2 || report.TopScorers[0].Total != 13 || report.TopScorers[1].ID != 2 || report.TopScorers[1].Total != 6 {
+		t.Fatalf("unexpected top scorers: %+v", report.TopScorers)
+	}
+	if _, ok := report.totals[4]; ok || report.totals[3] != 4 {
+		t.Fatalf("unexpected totals: %v", report.totals)
+	}
+}
+
+func TestMarkLiveChanges(t *testing.T) {
+	prev := &liveReport{
+		Fixtures: []liveFixture{{ID: 1, HomeScore: 1, Status: "live"}, {ID: 2, Status: "live"}},
+		Events:   []liveEvent{{Fixture: 1, ID: 9, Kind: "goal", Count: 1}, {Fixture: 2, ID: 5, Kind: "goal", Count: 1}},
+		totals:   map[int]int{9: 7},
+	}
+	cur := &liveReport{
+		Fixtures:   []liveFixture{{ID: 1, HomeScore: 2, Status: "live"}, {ID: 2, Status: "live"}},
+		Events:     []liveEvent{{Fixture: 1, ID: 9, Kind: "goal", Count: 2}, {Fixture: 1, ID: 8, Kind: "assist", Count: 1}, {Fixture: 2, ID: 5, Kind: "goal", Count: 1}},
+		TopScorers: []liveScorer{{ID: 9, Total: 13}, {ID: 5, Total: 2}},
+	}
+
+	markLiveChanges(nil, cur)
+	if cur.Fixtures[0].Changed || cur.Events[0].New || cur.TopScorers[0].Change != 0 {
+		t.Fatal("expected nothing flagged on the first poll")
+	}
+	markLiveChanges(prev, cur)
+	if !cur.Fixtures[0].Changed || cur.Fixtures[1].Changed {
+		t.Fatalf("expected only the first fixture to change, got %+v", cur.Fixtures)
+	}
+	if !cur.Events[0].New || !cur.Events[1].New || cur.Events[2].New {
+		t.Fatalf("expected the second goal and the assist to be new, got %+v", cur.Events)
+	}
+	if cur.TopScorers[0].Change != 6 || cur.TopScorers[1].Change != 2 {
+		t.Fatalf("expected changes of 6 and 2, got %+v", cur.TopScorers)
+	}
+}
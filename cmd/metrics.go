@@ -0,0 +1,95 @@
+package cmd
+
+import "math"
+
+const (
+	haulThreshold  = 10
+	blankThreshold = 2
+)
+
+// derivedMetrics holds the rate and consistency stats computed from a
+// selection of gameweek rows.
+type derivedMetrics struct {
+	GoalsPer90     float64 `json:"goals_per_90"`
+	AssistsPer90   float64 `json:"assists_per_90"`
+	XGIPer90       float64 `json:"xgi_per_90"`
+	PointsPer90    float64 `json:"points_per_90"`
+	PointsPerMatch float64 `json:"points_per_match"`
+	PointsPerStart float64 `json:"points_per_start"`
+	RollingAvg3    float64 `json:"rolling_avg_3"`
+	RollingAvg5    float64 `json:"rolling_avg_5"`
+	PointsStdDev   float64 `json:"points_std_dev"`
+	HaulRate       float64 `json:"haul_rate"`
+	BlankRate      float64 `json:"blank_rate"`
+}
+
+// deriveMetrics expects rows in gameweek order; rolling averages describe the
+// most recent fixtures. Rates are fractions in [0, 1].
+func deriveMetrics(rows []historyRow, totals historyTotals) derivedMetrics {
+	var m derivedMetrics
+	if len(rows) == 0 {
+		return m
+	}
+
+	points := make([]float64, len(rows))
+	var startPoints, hauls, blanks int
+	var xgi float64
+	for i, row := range rows {
+		points[i] = float64(row.Points)
+		xgi += row.XGI
+		if row.Starts > 0 {
+			startPoints += row.Points
+		}
+		if row.Points >= haulThreshold {
+			hauls++
+		}
+		if row.Points <= blankThreshold {
+			blanks++
+		}
+	}
+
+	if totals.Minutes > 0 {
+		per90 := 90 / float64(totals.Minutes)
+		m.GoalsPer90 = float64(totals.Goals) * per90
+		m.AssistsPer90 = float64(totals.Assists) * per90
+		m.XGIPer90 = xgi * per90
+		m.PointsPer90 = float64(totals.Points) * per90
+	}
+	if totals.Starts > 0 {
+		m.PointsPerStart = float64(startPoints) / float64(totals.Starts)
+	}
+
+	matches := float64(len(rows))
+	m.PointsPerMatch = float64(totals.Points) / matches
+	m.RollingAvg3 = lastValue(rollingAverage(points, 3))
+	m.RollingAvg5 = lastValue(rollingAverage(points, 5))
+	m.PointsStdDev = stdDev(points)
+	m.HaulRate = float64(hauls) / matches
+	m.BlankRate = float64(blanks) / matches
+	return m
+}
+
+// stdDev is the population standard deviation of values.
+func stdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
+
+func lastValue(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
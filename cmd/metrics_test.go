@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDeriveMetrics(t *testing.T) {
+	rows := []historyRow{
+		{Round: 1, Minutes: 90, Starts: 1, Goals: 1, Points: 8, XGI: 0.6},
+		{Round: 2, Minutes: 90, Starts: 1, Points: 2, XGI: 0.2},
+		{Round: 3, Minutes: 20, Points: 1},
+		{Round: 4, Minutes: 90, Starts: 1, Goals: 2, Assists: 1, Points: 15, XGI: 1.6},
+	}
+	totals := historyTotals{Minutes: 290, Starts: 3, Goals: 3, Assists: 1, Points: 26}
+
+	m := deriveMetrics(rows, totals)
+
+	assertClose(t, "goals per 90", m.GoalsPer90, 3*90.0/290)
+	assertClose(t, "xgi per 90", m.XGIPer90, 2.4*90/290)
+	assertClose(t, "points per start", m.PointsPerStart, 25.0/3)
+	assertClose(t, "points per match", m.PointsPerMatch, 6.5)
+	assertClose(t, "rolling avg 3", m.RollingAvg3, 6)
+	assertClose(t, "rolling avg 5", m.RollingAvg5, 6.5)
+	assertClose(t, "haul rate", m.HaulRate, 0.25)
+	assertClose(t, "blank rate", m.BlankRate, 0.5)
+	assertClose(t, "std dev", m.PointsStdDev, math.Sqrt((1.5*1.5+4.5*4.5+5.5*5.5+8.5*8.5)/4))
+}
+
+func TestDeriveMetricsEmpty(t *testing.T) {
+	if m := deriveMetrics(nil, historyTotals{}); m != (derivedMetrics{}) {
+		t.Fatalf("expected zero metrics, got %+v", m)
+	}
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("%s: got %v want %v", name, got, want)
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/optimize"
+	"github.com/lpoulter1/fpl-cli/internal/projection"
+	"github.com/spf13/cobra"
+)
+
+const (
+	objectiveTotalPoints = "total_points"
+	objectiveForm        = "form"
+	objectiveEPNext      = "ep_next"
+	objectiveXPts        = "xpts"
+	objectiveCustom      = "custom"
+)
+
+var objectives = []string{objectiveTotalPoints, objectiveForm, objectiveEPNext, objectiveXPts, objectiveCustom}
+
+// objectiveHelp describes each objective for command help.
+const objectiveHelp = `Objectives:
+  total_points  points per game this season, per fixture in the horizon
+  form          FPL form (average points over the last 30 days), per fixture
+  ep_next       FPL's expected points for the next gameweek, per fixture
+  xpts          the fpl predict model (minutes, xG, xA, clean sheet odds,
+                saves and bonus against each opponent)
+  custom        totals read from --projection-file (CSV of id,points)`
+
+// objectiveOptions chooses how players are scored over upcoming gameweeks.
+// It is shared by the commands that search for squads.
+type objectiveOptions struct {
+	objective      string
+	projectionFile string
+	horizon        int
+	benchWeight    float64
+}
+
+func (o *objectiveOptions) register(cmd *cobra.Command, horizon int) {
+	cmd.Flags().StringVar(&o.objective, "objective", objectiveTotalPoints, "what to maximize: "+strings.Join(objectives, ", "))
+	cmd.Flags().StringVar(&o.projectionFile, "projection-file", "", "CSV of player id,points used by --objective custom")
+	cmd.Flags().IntVar(&o.horizon, "horizon", horizon, "number of upcoming gameweeks to score")
+	cmd.Flags().Float64Var(&o.benchWeight, "bench-weight", optimize.DefaultRules().BenchWeight, "share of a bench player's score that counts (0-1)")
+	cmd.RegisterFlagCompletionFunc("objective", cobra.FixedCompletions(objectives, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func (o *objectiveOptions) validate() error {
+	if !containsString(objectives, o.objective) {
+		return fmt.Errorf("invalid --objective %q: expected %s", o.objective, strings.Join(objectives, ", "))
+	}
+	if (o.objective == objectiveCustom) != (o.projectionFile != "") {
+		return errors.New("--projection-file is required with --objective custom and only used by it")
+	}
+	if o.benchWeight < 0 || o.benchWeight > 1 {
+		return fmt.Errorf("--bench-weight must be between 0 and 1, got %g", o.benchWeight)
+	}
+	return nil
+}
+
+// project scores every player for each gameweek of the horizon and returns
+// the scores alongside the horizon's fixtures. A custom projection file only
+// has totals, so it yields a single score per player and a nil horizon.
+func (o *objectiveOptions) project(ctx context.Context, client *fpl.Client, ix *fpl.PlayerIndex) (map[int][]float64, *horizon, error) {
+	if o.objective == objectiveCustom {
+		totals, err := readProjectionFile(o.projectionFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		scores := make(map[int][]float64, len(totals))
+		for id, v := range totals {
+			scores[id] = []float64{v}
+		}
+		return scores, nil, nil
+	}
+	h, err := loadHorizon(ctx, client, ix, o.horizon)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gameweekScores(ix.Bootstrap().Elements, o.projection(ix.Bootstrap(), h), h.Gameweeks), h, nil
+}
+
+// projection returns the Projection behind a non-custom objective.
+func (o *objectiveOptions) projection(b *fpl.BootstrapStatic, h *horizon) projection.Projection {
+	switch o.objective {
+	case objectiveXPts:
+		return projection.NewModel(b, h.Schedule, h.Next)
+	case objectiveForm:
+		return projection.NewRate(b, h.Schedule, h.Next, func(el *fpl.Element) float64 { return parseStat(el.Form) })
+	case objectiveEPNext:
+		// ep_next already covers a double gameweek; spread it per fixture.
+		return projection.NewRate(b, h.Schedule, h.Next, func(el *fpl.Element) float64 {
+			return parseStat(el.EPNext) / math.Max(1, float64(h.count(el.Team, h.Next)))
+		})
+	}
+	return projection.NewRate(b, h.Schedule, h.Next, func(el *fpl.Element) float64 { return parseStat(el.PointsPerGame) })
+}
+
+// gameweekScores projects each player's points in every gameweek.
+func gameweekScores(elements []fpl.Element, p projection.Projection, gameweeks []int) map[int][]float64 {
+	scores := make(map[int][]float64, len(elements))
+	for _, el := range elements {
+		weeks := make([]float64, len(gameweeks))
+		for i, gw := range gameweeks {
+			weeks[i] = p.Project(el.ID, gw).Points
+		}
+		scores[el.ID] = weeks
+	}
+	return scores
+}
+
+// totalScores sums each player's gameweek scores.
+func totalScores(perGW map[int][]float64) map[int]float64 {
+	totals := make(map[int]float64, len(perGW))
+	for id, weeks := range perGW {
+		sum := 0.0
+		for _, v := range weeks {
+			sum += v
+		}
+		totals[id] = sum
+	}
+	return totals
+}
+
+// readProjectionFile parses a CSV of player id and projected points. A header
+// row is allowed and ignored.
+func readProjectionFile(path string) (map[int]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseProjections(f)
+}
+
+func parseProjections(in io.Reader) (map[int]float64, error) {
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = -1
+	r.Comment = '#'
+	scores := make(map[int]float64)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("projection line %d: expected id,points", line)
+		}
+		id, idErr := strconv.Atoi(strings.TrimSpace(record[0]))
+		points, ptsErr := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if idErr != nil || ptsErr != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("projection line %d: expected id,points, got %q", line, strings.Join(record, ","))
+		}
+		scores[id] = points
+	}
+	if len(scores) == 0 {
+		return nil, errors.New("projection file has no rows")
+	}
+	return scores, nil
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/projection"
+)
+
+func TestParseProjections(t *testing.T) {
+	scores, err := parseProjections(strings.NewReader("id,points\n# comment\n1, 6.5\n2,3\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scores) != 2 || scores[1] != 6.5 || scores[2] != 3 {
+		t.Fatalf("unexpected scores %v", scores)
+	}
+	if _, err := parseProjections(strings.NewReader("1,2\nSalah,4\n")); err == nil {
+		t.Fatal("expected a bad row after the first line to fail")
+	}
+	if _, err := parseProjections(strings.NewReader("id,points\n")); err == nil {
+		t.Fatal("expected an empty file to fail")
+	}
+}
+
+func TestGameweekScores(t *testing.T) {
+	event := func(gw int) *int { return &gw }
+	half := 50
+	// Team 1 doubles in GW5 and blanks in GW6.
+	fixtures := []fpl.Fixture{
+		{ID: 1, Event: event(5), TeamH: 1, TeamA: 3},
+		{ID: 2, Event: event(5), TeamH: 4, TeamA: 1},
+		{ID: 3, Event: event(5), TeamH: 2, TeamA: 5},
+		{ID: 4, Event: event(6), TeamH: 3, TeamA: 2},
+	}
+	h := &horizon{Gameweeks: []int{5, 6}, Next: 5, Schedule: projection.NewSchedule(fixtures)}
+	elements := []fpl.Element{
+		{ID: 10, Team: 1, Form: "4.0", EPNext: "8.0"},
+		{ID: 20, Team: 2, Form: "4.0", EPNext: "4.0", ChanceOfPlayingNextRound: &half},
+		{ID: 30, Team: 2, Form: "9.0", Status: "u"},
+	}
+
+	b := &fpl.BootstrapStatic{Elements: elements}
+	score := func(objective string) map[int]float64 {
+		o := &objectiveOptions{objective: objective}
+		return totalScores(gameweekScores(elements, o.projection(b, h), h.Gameweeks))
+	}
+	form := score(objectiveForm)
+	want := map[int]float64{10: 8, 20: 6, 30: 0}
+	for id, w := range want {
+		if math.Abs(form[id]-w) > 1e-9 {
+			t.Fatalf("form score for %d: got %.2f want %.2f", id, form[id], w)
+		}
+	}
+	ep := score(objectiveEPNext)
+	if math.Abs(ep[10]-8) > 1e-9 || math.Abs(ep[20]-6) > 1e-9 {
+		t.Fatalf("unexpected ep_next scores %v", ep)
+	}
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/optimize"
+	"github.com/spf13/cobra"
+)
+
+// optimizeReportVersion is the schema_version of optimizeReport JSON output.
+const optimizeReportVersion = 1
+
+// optimizePlayerVersion is the schema_version of each fpl optimize NDJSON
+// line.
+const optimizePlayerVersion = 1
+
+type optimizeOptions struct {
+	objectiveOptions
+	budget   float64
+	locks    []string
+	excludes []string
+}
+
+type optimizeReport struct {
+	SchemaVersion int           `json:"schema_version"`
+	Objective     string        `json:"objective"`
+	Gameweeks     []int         `json:"gameweeks"`
+	Budget        float64       `json:"budget"`
+	Cost          float64       `json:"cost"`
+	Bank          float64       `json:"bank"`
+	Formation     string        `json:"formation"`
+	BenchWeight   float64       `json:"bench_weight"`
+	Score         float64       `json:"score"`
+	XIScore       float64       `json:"xi_score"`
+	Players       []squadPlayer `json:"players"`
+}
+
+type squadPlayer struct {
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	Team     string  `json:"team"`
+	Position string  `json:"position"`
+	Cost     float64 `json:"cost"`
+	Score    float64 `json:"score"`
+	Starter  bool    `json:"starter"`
+	Locked   bool    `json:"locked"`
+}
+
+// optimizePlayerLine is one squad player as streamed with --output ndjson.
+type optimizePlayerLine struct {
+	SchemaVersion int `json:"schema_version"`
+	squadPlayer
+}
+
+func newOptimizeCmd() *cobra.Command {
+	opts := &optimizeOptions{}
+	rules := optimize.DefaultRules()
+	cmd := &cobra.Command{
+		Use:   "optimize",
+		Short: "Pick the best 15-man squad and starting XI under FPL rules",
+		Long: `Select the 15-player squad (2 GKP, 5 DEF, 5 MID, 3 FWD, at most 3 per club,
+within budget) and starting XI that maximize an objective over the next
+gameweeks. The search is exact: it proves no other legal squad scores more.
+
+` + objectiveHelp + `
+
+Fixture counts come from the season's fixture list, so blank and double
+gameweeks are accounted for. The next gameweek is scaled by each player's
+chance of playing. Bench players count for --bench-weight of their score.`,
+		Example: `  fpl optimize
+  fpl optimize --objective form --horizon 5
+  fpl optimize --lock Salah --lock Haaland --exclude 123 --budget 101.5
+  fpl optimize --objective custom --projection-file my-projections.csv --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOptimize(cmd.Context(), cmd, opts)
+		},
+	}
+
+	opts.register(cmd, 1)
+	cmd.Flags().Float64Var(&opts.budget, "budget", float64(rules.Budget)/10, "squad budget in £m")
+	cmd.Flags().StringArrayVar(&opts.locks, "lock", nil, "player name or ID that must be in the squad (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.excludes, "exclude", nil, "player name or ID to leave out (repeatable)")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newOptimizeCmd())
+	registerReportSchema(reportSchema{
+		Name:        "optimize",
+		Version:     optimizeReportVersion,
+		Description: "Optimal squad, starting XI and objective values from fpl optimize --json",
+		Sample:      optimizeReport{},
+	})
+	registerReportSchema(reportSchema{
+		Name:        "optimize-player",
+		Version:     optimizePlayerVersion,
+		Description: "One squad player per line from fpl optimize --output ndjson",
+		Sample:      optimizePlayerLine{},
+	})
+}
+
+func runOptimize(ctx context.Context, cmd *cobra.Command, opts *optimizeOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	rules := optimize.DefaultRules()
+	rules.Budget = int(math.Round(opts.budget * 10))
+	rules.BenchWeight = opts.benchWeight
+
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+
+	resolver := newPlayerResolver(cmd, &playerOptions{strict: true}, ix)
+	locked, err := resolvePlayerIDs(resolver, opts.locks)
+	if err != nil {
+		return fmt.Errorf("--lock: %w", err)
+	}
+	excluded, err := resolvePlayerIDs(resolver, opts.excludes)
+	if err != nil {
+		return fmt.Errorf("--exclude: %w", err)
+	}
+
+	perGW, h, err := opts.project(ctx, client, ix)
+	if err != nil {
+		return err
+	}
+
+	result, err := optimize.Solve(squadCandidates(bootstrap.Elements, totalScores(perGW), locked), rules, locked, excluded)
+	if errors.Is(err, optimize.ErrInfeasible) {
+		return fmt.Errorf("%w; try a larger --budget or fewer --lock values", err)
+	}
+	if err != nil {
+		return err
+	}
+	report := buildOptimizeReport(result, ix, opts, rules, h.gameweeks(), locked)
+
+	out := cmd.OutOrStdout()
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, p := range report.Players {
+			if err := enc.Encode(optimizePlayerLine{optimizePlayerVersion, p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return writeCSV(out, squadColumns, report.Players)
+	}
+	printOptimizeTable(out, report, result.Nodes)
+	return nil
+}
+
+func resolvePlayerIDs(resolver *playerResolver, values []string) ([]int, error) {
+	ids := make([]int, 0, len(values))
+	for _, v := range values {
+		target, _, err := resolver.resolve(parsePlayerRef(v))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, target.ID)
+	}
+	return ids, nil
+}
+
+// squadCandidates turns elements into solver inputs, leaving out players who
+// have left their club unless they are locked.
+func squadCandidates(elements []fpl.Element, scores map[int]float64, locked []int) []optimize.Candidate {
+	keep := make(map[int]bool, len(locked))
+	for _, id := range locked {
+		keep[id] = true
+	}
+	cands := make([]optimize.Candidate, 0, len(elements))
+	for _, el := range elements {
+		if el.Status == "u" && !keep[el.ID] {
+			continue
+		}
+		cands = append(cands, optimize.Candidate{
+			ID:       el.ID,
+			Team:     el.Team,
+			Position: el.ElementType,
+			Cost:     el.NowCost,
+			Score:    scores[el.ID],
+		})
+	}
+	return cands
+}
+
+func buildOptimizeReport(r *optimize.Result, ix *fpl.PlayerIndex, opts *optimizeOptions, rules optimize.Rules, gameweeks []int, locked []int) optimizeReport {
+	isLocked := make(map[int]bool, len(locked))
+	for _, id := range locked {
+		isLocked[id] = true
+	}
+	report := optimizeReport{
+		SchemaVersion: optimizeReportVersion,
+		Objective:     opts.objective,
+		Gameweeks:     gameweeks,
+		Budget:        float64(rules.Budget) / 10,
+		Cost:          float64(r.Cost) / 10,
+		Bank:          float64(rules.Budget-r.Cost) / 10,
+		Formation:     r.Formation.String(),
+		BenchWeight:   rules.BenchWeight,
+		Score:         roundTo(r.Objective, 2),
+		XIScore:       roundTo(r.XIScore, 2),
+		Players:       make([]squadPlayer, 0, len(r.Picks)),
+	}
+	for _, p := range r.Picks {
+		el := ix.Player(p.ID)
+		team := ix.Team(p.Team)
+		pos := ix.Position(p.Position)
+		sp := squadPlayer{
+			ID:      p.ID,
+			Cost:    float64(p.Cost) / 10,
+			Score:   roundTo(p.Score, 2),
+			Starter: p.Starter,
+			Locked:  isLocked[p.ID],
+		}
+		if el != nil {
+			sp.Name = el.WebName
+		}
+		if team != nil {
+			sp.Team = team.ShortName
+		}
+		if pos != nil {
+			sp.Position = pos.SingularNameShort
+		}
+		report.Players = append(report.Players, sp)
+	}
+	return report
+}
+
+var squadColumns = []tableColumn[squadPlayer]{
+	{Key: "role", Header: "Role", Priority: 0, Value: func(p squadPlayer) string {
+		if p.Starter {
+			return "XI"
+		}
+		return "Bench"
+	}},
+	{Key: "position", Header: "Pos", Priority: 0, Value: func(p squadPlayer) string { return p.Position }},
+	{Key: "player", Header: "Player", Priority: 0, Value: func(p squadPlayer) string {
+		if p.Locked {
+			return p.Name + " *"
+		}
+		return p.Name
+	}},
+	{Key: "team", Header: "Team", Priority: 2, Value: func(p squadPlayer) string { return p.Team }},
+	intColumn("id", "ID", 3, func(p squadPlayer) int { return p.ID }),
+	{Key: "cost", Header: "£", Priority: 1, AlignRight: true, Value: func(p squadPlayer) string { return fmt.Sprintf("%.1f", p.Cost) }},
+	floatColumn("score", "Score", 0, func(p squadPlayer) float64 { return p.Score }),
+}
+
+func printOptimizeTable(out io.Writer, report optimizeReport, nodes int) {
+	colors := newPalette(out, rootOpts.color)
+	span := "custom projection"
+	if len(report.Gameweeks) > 0 {
+		span = "GW " + formatGWList(report.Gameweeks)
+	}
+	fmt.Fprintf(out, "%s | %s | %s | £%.1fm of £%.1fm (bank £%.1fm)\n\n",
+		colors.bold("Optimal squad "+report.Formation),
+		report.Objective,
+		span,
+		report.Cost,
+		report.Budget,
+		report.Bank,
+	)
+	visible := fitColumns(squadColumns, report.Players, terminalWidth(out))
+	renderTable(out, visible, report.Players, colors)
+	fmt.Fprintf(out, "\nXI score %.2f | Objective %.2f (bench weight %.2f) | proven optimal after %d relaxations\n",
+		report.XIScore, report.Score, report.BenchWeight, nodes)
+	for _, p := range report.Players {
+		if p.Locked {
+			fmt.Fprintln(out, colors.dim("* locked"))
+			break
+		}
+	}
+}
+
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/projection"
+	"github.com/spf13/cobra"
+)
+
+// picksReportVersion is the schema_version of picksReport JSON output.
+const picksReportVersion = 1
+
+// pickRowVersion is the schema_version of each fpl picks NDJSON line.
+const pickRowVersion = 1
+
+type picksOptions struct {
+	entry int
+	gw    string
+}
+
+type picksReport struct {
+	SchemaVersion int    `json:"schema_version"`
+	Entry         int    `json:"entry"`
+	EntryName     string `json:"entry_name"`
+	Gameweek      int    `json:"gameweek"`
+	Chip          string `json:"chip,omitempty"`
+	// LivePoints is the score so far as picked, before automatic
+	// substitutions and provisional bonus; ProjectedPoints adds both, plus
+	// expected points for players yet to play. Both are before transfer
+	// hits.
+	LivePoints int `json:"live_points"`
+	// ProvisionalBonus is the bonus the picks would earn from live BPS in
+	// matches whose bonus is not yet confirmed.
+	ProvisionalBonus int     `json:"provisional_bonus"`
+	ProjectedPoints  float64 `json:"projected_points"`
+	TransferCost     int     `json:"transfer_cost"`
+	// Finished is true once every fixture of the gameweek has reached full
+	// time.
+	Finished bool           `json:"finished"`
+	Captain  int            `json:"captain"`
+	AutoSubs []hindsightSub `json:"auto_subs"`
+	Picks    []pickRow      `json:"picks"`
+}
+
+type pickRow struct {
+	Slot     int    `json:"slot"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Team     string `json:"team"`
+	Position string `json:"position"`
+	Captain  bool   `json:"is_captain"`
+	Vice     bool   `json:"is_vice_captain"`
+	// Status is "played", "playing", "yet to play" or "did not play".
+	Status  string `json:"status"`
+	Minutes int    `json:"minutes"`
+	Points  int    `json:"points"`
+	// ProvisionalBonus is bonus from live BPS not yet in Points.
+	ProvisionalBonus int `json:"provisional_bonus"`
+	Multiplier       int `json:"multiplier"`
+	// Projected is the player's expected final points and
+	// ProjectedMultiplier what they count with after automatic
+	// substitutions.
+	Projected           float64 `json:"projected"`
+	ProjectedMultiplier int     `json:"projected_multiplier"`
+	// Sub is "in" or "out" for players in a projected automatic
+	// substitution.
+	Sub string `json:"sub,omitempty"`
+}
+
+// pickRowLine is one pick as streamed with --output ndjson.
+type pickRowLine struct {
+	SchemaVersion int `json:"schema_version"`
+	pickRow
+}
+
+func newPicksCmd() *cobra.Command {
+	opts := &picksOptions{}
+	cmd := &cobra.Command{
+		Use:   "picks",
+		Short: "Show a manager's picks with live and projected points",
+		Long: `Show a manager's picks for a gameweek with each player's live points and the
+projected final score once automatic substitutions are made.
+
+Substitutions follow the FPL rules: a starter who did not play is replaced by
+the first bench player, in bench order, who keeps the formation legal (one
+goalkeeper, at least three defenders, two midfielders and one forward), with
+goalkeepers only swapped for goalkeepers. The vice-captain takes the armband
+when the captain does not play. While matches are still to come, players
+whose fixtures have not kicked off are projected with the fpl predict model,
+and injured or suspended ones are projected not to play.`,
+		Example: `  fpl picks --entry 123456
+  fpl picks --gw 7
+  fpl picks --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPicks(cmd.Context(), cmd, opts)
+		},
+	}
+	addEntryFlag(cmd, &opts.entry)
+	cmd.Flags().StringVar(&opts.gw, "gw", "current", "gameweek: current or a number")
+	cmd.RegisterFlagCompletionFunc("gw", completeGameweeks)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newPicksCmd())
+	registerReportSchema(reportSchema{
+		Name:        "picks",
+		Version:     picksReportVersion,
+		Description: "A manager's picks with live and projected points from fpl picks --json",
+		Sample:      picksReport{},
+	})
+	registerReportSchema(reportSchema{
+		Name:        "picks-row",
+		Version:     pickRowVersion,
+		Description: "One pick with live and projected points per line from fpl picks --output ndjson",
+		Sample:      pickRowLine{},
+	})
+}
+
+func runPicks(ctx context.Context, cmd *cobra.Command, opts *picksOptions) error {
+	entryID, err := resolveEntry(cmd, opts.entry)
+	if err != nil {
+		return err
+	}
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+	gw, err := resolveGameweek(bootstrap.Events, opts.gw)
+	if err != nil {
+		return err
+	}
+	entry, err := client.Entry(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	picks, err := client.EntryPicks(ctx, entryID, gw)
+	if err != nil {
+		return err
+	}
+	live, err := client.EventLive(ctx, gw)
+	if err != nil {
+		return err
+	}
+	fixtures, err := client.Fixtures(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := buildPicksReport(ix, gw, picks, live.Stats(), fixtures)
+	if err != nil {
+		return err
+	}
+	report.Entry, report.EntryName = entryID, entry.Name
+
+	out := cmd.OutOrStdout()
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, p := range report.Picks {
+			if err := enc.Encode(pickRowLine{pickRowVersion, p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return writeCSV(out, pickColumns, report.Picks)
+	}
+	printPicks(out, report)
+	return nil
+}
+
+// buildPicksReport scores a gameweek's picks so far and projects the final
+// score with automatic substitutions.
+func buildPicksReport(ix *fpl.PlayerIndex, gw int, picks *fpl.EntryPicks, stats map[int]fpl.LiveStats, fixtures []fpl.Fixture) (picksReport, error) {
+	report := picksReport{
+		SchemaVersion: picksReportVersion,
+		Gameweek:      gw,
+		Chip:          picks.ActiveChip,
+		TransferCost:  picks.EntryHistory.EventTransfersCost,
+		AutoSubs:      []hindsightSub{},
+	}
+	byTeam := fixturesByTeam(fixtures, gw)
+	var gwFixtures []fpl.Fixture
+	for _, f := range fixtures {
+		if f.Event != nil && *f.Event == gw {
+			gwFixtures = append(gwFixtures, f)
+		}
+	}
+	report.Finished = allFinished(gwFixtures)
+	bonus := fpl.ProvisionalBonuses(fixtures, gw)
+	// Players yet to kick off are projected for the gameweek being played,
+	// scaled by their chance of playing this round; gw+1 is the next one.
+	model := projection.NewModel(ix.Bootstrap(), projection.NewSchedule(fixtures), gw+1)
+
+	squad := make([]fpl.SquadPlayer, 0, len(picks.Picks))
+	for _, p := range picks.Picks {
+		el := ix.Player(p.Element)
+		if el == nil {
+			return report, fmt.Errorf("player %d is missing from bootstrap data", p.Element)
+		}
+		s := stats[el.ID]
+		status := playStatus(s, byTeam[el.Team])
+		row := pickRow{
+			Slot:             p.Position,
+			ID:               el.ID,
+			Name:             el.WebName,
+			Team:             teamShortName(ix, el.Team),
+			Captain:          p.IsCaptain,
+			Vice:             p.IsViceCaptain,
+			Status:           statusLabel(status, byTeam[el.Team]),
+			Minutes:          s.Minutes,
+			Points:           s.TotalPoints,
+			Multiplier:       p.Multiplier,
+			ProvisionalBonus: bonus[el.ID],
+			Projected:        float64(s.TotalPoints + bonus[el.ID]),
+		}
+		if pos := ix.Position(el.ElementType); pos != nil {
+			row.Position = pos.SingularNameShort
+		}
+		if status == fpl.Pending {
+			// Players who cannot play are expected to be substituted.
+			if el.Status == "i" || el.Status == "s" || el.Status == "u" || el.Status == "n" {
+				status = fpl.DidNotPlay
+			} else if !anyStarted(byTeam[el.Team]) {
+				row.Projected = model.Project(el.ID, gw).Points
+			}
+		}
+		report.LivePoints += s.TotalPoints * p.Multiplier
+		report.ProvisionalBonus += bonus[el.ID] * p.Multiplier
+		report.Picks = append(report.Picks, row)
+		squad = append(squad, fpl.SquadPlayer{Pick: p, ElementType: el.ElementType, Status: status})
+	}
+
+	lineup := fpl.ApplyAutoSubs(squad, picks.ActiveChip)
+	report.Captain = lineup.Captain
+	subs := make(map[int]string, 2*len(lineup.Subs))
+	for _, sub := range lineup.Subs {
+		subs[sub.ElementOut], subs[sub.ElementIn] = "out", "in"
+	}
+	for i := range report.Picks {
+		row := &report.Picks[i]
+		row.ProjectedMultiplier = lineup.Multipliers[row.ID]
+		row.Projected = roundTo(row.Projected, 2)
+		row.Sub = subs[row.ID]
+		report.ProjectedPoints += row.Projected * float64(row.ProjectedMultiplier)
+	}
+	for _, sub := range lineup.Subs {
+		report.AutoSubs = append(report.AutoSubs, hindsightSub{Out: pickPlayer(report.Picks, sub.ElementOut), In: pickPlayer(report.Picks, sub.ElementIn)})
+	}
+	report.ProjectedPoints = roundTo(report.ProjectedPoints, 2)
+	return report, nil
+}
+
+func pickPlayer(rows []pickRow, id int) hindsightPlayer {
+	for _, r := range rows {
+		if r.ID == id {
+			return hindsightPlayer{ID: r.ID, Name: r.Name, Position: r.Position, Points: r.Points}
+		}
+	}
+	return hindsightPlayer{ID: id}
+}
+
+// fixturesByTeam indexes gameweek gw's fixtures by each side.
+func fixturesByTeam(fixtures []fpl.Fixture, gw int) map[int][]fpl.Fixture {
+	byTeam := make(map[int][]fpl.Fixture)
+	for _, f := range fixtures {
+		if f.Event != nil && *f.Event == gw {
+			byTeam[f.TeamH] = append(byTeam[f.TeamH], f)
+			byTeam[f.TeamA] = append(byTeam[f.TeamA], f)
+		}
+	}
+	return byTeam
+}
+
+// playStatus is whether a player has played given their team's fixtures in
+// the gameweek: without minutes they did not play once every fixture has
+// finished, or straight away in a blank gameweek.
+func playStatus(s fpl.LiveStats, fixtures []fpl.Fixture) fpl.PlayStatus {
+	if s.Minutes > 0 {
+		return fpl.Played
+	}
+	if !allFinished(fixtures) {
+		return fpl.Pending
+	}
+	return fpl.DidNotPlay
+}
+
+// allFinished reports whether every fixture has reached full time.
+func allFinished(fixtures []fpl.Fixture) bool {
+	for _, f := range fixtures {
+		if !f.Finished && !f.FinishedProvisional {
+			return false
+		}
+	}
+	return true
+}
+
+func anyStarted(fixtures []fpl.Fixture) bool {
+	for _, f := range fixtures {
+		if f.Started {
+			return true
+		}
+	}
+	return false
+}
+
+func statusLabel(status fpl.PlayStatus, fixtures []fpl.Fixture) string {
+	if status == fpl.DidNotPlay {
+		return "did not play"
+	}
+	for _, f := range fixtures {
+		if f.Started && !f.Finished && !f.FinishedProvisional {
+			return "playing"
+		}
+	}
+	if status == fpl.Played {
+		return "played"
+	}
+	return "yet to play"
+}
+
+func pickBadge(p pickRow) string {
+	switch {
+	case p.Captain:
+		return "C"
+	case p.Vice:
+		return "V"
+	}
+	return ""
+}
+
+func pickSlot(p pickRow) string {
+	if p.Slot > 11 {
+		return fmt.Sprintf("B%d", p.Slot-11)
+	}
+	return "XI"
+}
+
+func subArrow(p pickRow) string {
+	switch p.Sub {
+	case "in":
+		return "↑ in"
+	case "out":
+		return "↓ out"
+	}
+	return ""
+}
+
+var pickColumns = []tableColumn[pickRow]{
+	{Key: "slot", Header: "", Priority: 1, Value: pickSlot},
+	{Key: "player", Header: "Player", Priority: 0, Value: func(p pickRow) string { return p.Name }},
+	{Key: "role", Header: "C", Priority: 1, Value: pickBadge},
+	{Key: "team", Header: "Team", Priority: 2, Value: func(p pickRow) string { return p.Team }},
+	{Key: "position", Header: "Pos", Priority: 3, Value: func(p pickRow) string { return p.Position }},
+	{Key: "status", Header: "Status", Priority: 1, Value: func(p pickRow) string { return p.Status }},
+	intColumn("minutes", "Min", 3, func(p pickRow) int { return p.Minutes }),
+	intColumn("points", "Pts", 0, func(p pickRow) int { return p.Points }),
+	{Key: "provisional_bonus", Header: "Bonus", Priority: 2, AlignRight: true, Value: func(p pickRow) string { return provisionalText(p.ProvisionalBonus) }},
+	floatColumn("projected", "Proj", 1, func(p pickRow) float64 { return p.Projected }),
+	intColumn("projected_multiplier", "×", 1, func(p pickRow) int { return p.ProjectedMultiplier }),
+	{Key: "sub", Header: "Sub", Priority: 2, Value: subArrow},
+}
+
+func printPicks(out io.Writer, report picksReport) {
+	colors := newPalette(out, rootOpts.color)
+	title := fmt.Sprintf("%s | %s | GW %d", colors.bold("Picks"), report.EntryName, report.Gameweek)
+	if report.Chip != "" {
+		title += " | " + chipLabel(report.Chip)
+	}
+	fmt.Fprintln(out, title)
+	fmt.Fprintln(out)
+	visible := fitColumns(pickColumns, report.Picks, terminalWidth(out))
+	renderTable(out, visible, report.Picks, colors)
+
+	fmt.Fprintln(out)
+	if len(report.AutoSubs) > 0 {
+		parts := make([]string, len(report.AutoSubs))
+		for i, s := range report.AutoSubs {
+			parts[i] = fmt.Sprintf("%s → %s", s.Out.Name, s.In.Name)
+		}
+		fmt.Fprintf(out, "Auto-subs: %s\n", strings.Join(parts, ", "))
+	}
+	label := "Projected final"
+	if report.Finished {
+		label = "Final with auto-subs"
+	}
+	fmt.Fprintf(out, "Live %d pts", report.LivePoints)
+	if report.ProvisionalBonus > 0 {
+		fmt.Fprintf(out, " %s", provisionalText(report.ProvisionalBonus))
+	}
+	fmt.Fprintf(out, " | %s %.1f pts", label, report.ProjectedPoints)
+	if report.TransferCost > 0 {
+		fmt.Fprintf(out, " | -%d hit", report.TransferCost)
+	}
+	fmt.Fprintln(out)
+	if report.ProvisionalBonus > 0 {
+		fmt.Fprintln(out, colors.dim(provisionalNote))
+	}
+	if !report.Finished {
+		fmt.Fprintln(out, colors.dim("Players yet to kick off are projected with the fpl predict model."))
+	}
+}
+
+// provisionalNote explains provisional bonus wherever it is shown.
+const provisionalNote = "(+N?) is provisional bonus from live BPS, confirmed when each match's result is."
+
+// provisionalText marks unconfirmed bonus points, or is blank when there
+// are none.
+func provisionalText(bonus int) string {
+	if bonus == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(+%d?)", bonus)
+}
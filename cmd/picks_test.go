@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestBuildPicksReport(t *testing.T) {
+	gw := 3
+	// Team 1 has finished, team 2 is playing and team 3 has yet to kick off.
+	fixtures := []fpl.Fixture{
+		{ID: 1, Event: &gw, TeamH: 1, TeamA: 4, Started: true, Finished: true},
+		{ID: 2, Event: &gw, TeamH: 2, TeamA: 5, Started: true, Stats: []fpl.FixtureStat{
+			{Identifier: "bps", Home: []fpl.StatValue{{Element: 7, Value: 12}}, Away: []fpl.StatValue{{Element: 99, Value: 5}}},
+		}},
+		{ID: 3, Event: &gw, TeamH: 3, TeamA: 6},
+	}
+	b, picks, stats := testSquad(squadTypes, 9, 5)
+	b.Teams = []fpl.Team{{ID: 1, ShortName: "ONE"}, {ID: 2, ShortName: "TWO"}, {ID: 3, ShortName: "THR"}}
+	// The captain missed a finished match, midfielder 6 plays later and
+	// midfielder 7 leads the BPS in a match in progress.
+	stats[9] = fpl.LiveStats{}
+	b.Elements[5].Team, stats[6] = 3, fpl.LiveStats{}
+	b.Elements[6].Team, stats[7] = 2, fpl.LiveStats{Minutes: 20, TotalPoints: 1}
+	stats[5] = fpl.LiveStats{Minutes: 90, TotalPoints: 8}
+
+	report, err := buildPicksReport(fpl.NewPlayerIndex(b), gw, picks, stats, fixtures)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Seven starters on 2 each, midfielder 7's 1 and the vice-captain's 8,
+	// counted once.
+	if report.LivePoints != 23 || report.ProvisionalBonus != 3 {
+		t.Fatalf("expected 23 live points and 3 provisional bonus, got %d and %d", report.LivePoints, report.ProvisionalBonus)
+	}
+	if report.Finished || report.Captain != 5 {
+		t.Fatalf("expected an unfinished gameweek with the vice captain, got %+v", report)
+	}
+	if len(report.AutoSubs) != 1 || report.AutoSubs[0].Out.ID != 9 || report.AutoSubs[0].In.ID != 13 {
+		t.Fatalf("expected the first outfield sub to replace the captain, got %+v", report.AutoSubs)
+	}
+	// The doubled vice-captain's 16, seven starters and the substitute on
+	// 2 each and midfielder 7's 1 plus 3 bonus; midfielder 6 projects
+	// nothing without season data.
+	if report.ProjectedPoints != 36 {
+		t.Fatalf("expected 36 projected points, got %.2f", report.ProjectedPoints)
+	}
+	if got := report.Picks[5].Status; got != "yet to play" {
+		t.Fatalf("expected midfielder 6 yet to play, got %q", got)
+	}
+	if got := report.Picks[6].Status; got != "playing" {
+		t.Fatalf("expected midfielder 7 playing, got %q", got)
+	}
+
+	// The gameweek finishes with its last fixture, not its last player.
+	for id := range stats {
+		stats[id] = fpl.LiveStats{Minutes: 90}
+	}
+	if report, _ = buildPicksReport(fpl.NewPlayerIndex(b), gw, picks, stats, fixtures); report.Finished {
+		t.Fatal("expected the gameweek unfinished while fixtures are in progress")
+	}
+	fixtures[1].Finished, fixtures[2].FinishedProvisional = true, true
+	if report, _ = buildPicksReport(fpl.NewPlayerIndex(b), gw, picks, stats, fixtures); !report.Finished {
+		t.Fatal("expected the gameweek finished at the last full time")
+	}
+}
+
+func TestBuildPicksReportScalesDoubtfulPlayers(t *testing.T) {
+	gw, prev := 3, 2
+	fixtures := []fpl.Fixture{
+		{ID: 1, Event: &gw, TeamH: 1, TeamA: 4, Started: true, Finished: true},
+		{ID: 2, Event: &gw, TeamH: 2, TeamA: 5},
+		{ID: 3, Event: &prev, TeamH: 2, TeamA: 6, Finished: true},
+	}
+	b, picks, stats := testSquad(squadTypes, 0, 0)
+	// Midfielder 6 has started every match and has yet to kick off.
+	mid := &b.Elements[5]
+	mid.Team, mid.Minutes, mid.Starts = 2, 90, 1
+	stats[6] = fpl.LiveStats{}
+
+	projected := func() float64 {
+		report, err := buildPicksReport(fpl.NewPlayerIndex(b), gw, picks, stats, fixtures)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return report.Picks[5].Projected
+	}
+	fit := projected()
+	// After the deadline the next-round chance is about the next gameweek.
+	half, full := 50, 100
+	mid.Status, mid.ChanceOfPlayingThisRound, mid.ChanceOfPlayingNextRound = "d", &half, &full
+	if doubtful := projected(); fit <= 0 || math.Abs(doubtful-fit/2) > 0.01 {
+		t.Fatalf("expected a 50%% doubt this round to halve %.2f projected points, got %.2f", fit, doubtful)
+	}
+}
@@ -5,74 +5,137 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
 	"strings"
-	"text/tabwriter"
 
+	"github.com/lpoulter1/fpl-cli/internal/aliases"
 	"github.com/lpoulter1/fpl-cli/internal/fpl"
 	"github.com/spf13/cobra"
 )
 
 type playerOptions struct {
-	id   int
-	name string
-	gws  gwFlag
+	ids      []int
+	names    []string
+	fromFile string
+	team     string
+	position string
+	gws      gwFlag
+	chart    bool
+	columns  []string
+	sort     string
+	strict   bool
+	live     bool
+	explain  bool
 }
 
 func newPlayerCmd() *cobra.Command {
 	opts := &playerOptions{}
 	cmd := &cobra.Command{
 		Use:   "player",
-		Short: "Show stats for an FPL player",
-		Long: `Display Fantasy Premier League stats for a single player.
-
-You can identify the target by ID (exact) or by name (fuzzy match). Gameweeks
-can be filtered using --gw flags with single values or inclusive ranges.`,
+		Short: "Show stats for one or more FPL players",
+		Long: `Display Fantasy Premier League stats for one or more players.
+
+You can identify targets by ID (exact) or by name (fuzzy match). Repeat --id and
+--name, or pass --from-file with one name or ID per line ("-" reads stdin), to
+resolve a batch in one run; summaries are fetched concurrently and printed as a
+combined table, CSV or NDJSON stream. Gameweeks can be filtered using --gw flags
+with single values or inclusive ranges. --live adds the current gameweek's live
+points, BPS and provisional bonus for a single player, and --explain breaks
+their points down by scoring category.`,
 		Example: `  fpl player --id 123
   fpl player --name "Haaland"
   fpl player --name "Haaland" --gw 1-3
-  fpl player --name "Salah" --gw 1|4|6-8 --json`,
+  fpl player --name "Gordon NEW"
+  fpl player --name "Johnson" --team TOT --position MID
+  fpl player --name "Salah" --gw 1|4|6-8 --json
+  fpl player --name "Saka" --chart
+  fpl player --name "Saka" --live
+  fpl player --name "Palmer" --explain
+  fpl player --name "Palmer" --columns round,opponent,min,xg,pts --sort pts:desc
+  fpl player --name "Isak" --output csv
+  fpl player --name Salah --name Haaland --id 123 --gw 1-5
+  fpl player --from-file players.txt --output ndjson
+  cat players.txt | fpl player --from-file - --output csv`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPlayer(cmd.Context(), cmd, opts)
 		},
 	}
 
-	cmd.Flags().IntVar(&opts.id, "id", 0, "FPL player ID to query")
-	cmd.Flags().StringVar(&opts.name, "name", "", "player name to fuzzy match (web name, full name, or known-as)")
+	cmd.Flags().IntSliceVar(&opts.ids, "id", nil, "FPL player ID to query (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.names, "name", nil, "player name to fuzzy match (web name, full name, or known-as); accepts qualifiers like \"Gordon NEW\", team:LIV or pos:GKP (repeatable)")
+	cmd.Flags().StringVar(&opts.fromFile, "from-file", "", "read player names or IDs, one per line, from a file (\"-\" for stdin)")
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "fail instead of guessing when a --name match is ambiguous or low confidence")
+	cmd.Flags().StringVar(&opts.team, "team", "", "restrict --name matches to a club (short name like TOT, or full name)")
+	cmd.Flags().StringVar(&opts.position, "position", "", "restrict --name matches to a position (GKP, DEF, MID or FWD)")
+	cmd.Flags().BoolVar(&opts.chart, "chart", false, "draw sparkline trends for points, minutes and xGI beneath the table")
+	cmd.Flags().StringSliceVar(&opts.columns, "columns", nil, "comma-separated gameweek columns for table/CSV output; JSON keeps every column (e.g. round,opponent,min,xg,pts)")
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "sort gameweek rows by column[:asc|desc], comma-separated, in every output format (e.g. pts:desc,min:desc)")
+	cmd.Flags().BoolVar(&opts.live, "live", false, "show live points, BPS and provisional bonus for the current gameweek (single player only)")
+	cmd.Flags().BoolVar(&opts.explain, "explain", false, "break points down by scoring category per gameweek and in total (single player only)")
 	cmd.Flags().Var(&opts.gws, "gw", "filter to a specific gameweek or inclusive range (e.g. --gw 5 --gw 1-3 --gw 6|8)")
+	registerPlayerCompletions(cmd, opts)
 
 	return cmd
 }
 
 func init() {
 	rootCmd.AddCommand(newPlayerCmd())
+	registerReportSchema(reportSchema{
+		Name:        "player",
+		Version:     playerReportVersion,
+		Description: "Player metadata, per-gameweek rows and totals from fpl player --json",
+		Sample:      playerReport{},
+	})
 }
 
 func runPlayer(ctx context.Context, cmd *cobra.Command, opts *playerOptions) error {
-	if opts.id <= 0 && strings.TrimSpace(opts.name) == "" {
-		return errors.New("either --id or --name must be provided")
+	refs, err := collectPlayerRefs(cmd, opts)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 {
+		return errors.New("either --id, --name or --from-file must be provided")
+	}
+	if opts.gws.relative() {
+		return errors.New("--gw next selects upcoming gameweeks, which have no history yet; see fpl predict")
 	}
 
-	client := fpl.NewClient(nil, rootOpts.cacheTTL)
+	columns := historyColumns
+	if len(opts.columns) > 0 {
+		selected, err := selectColumns(opts.columns)
+		if err != nil {
+			return err
+		}
+		columns = selected
+	}
+	sortKeys, err := parseSortKeys(opts.sort)
+	if err != nil {
+		return err
+	}
+
+	client := newClient()
 	bootstrap, err := client.Bootstrap(ctx)
 	if err != nil {
 		return err
 	}
+	ix := fpl.NewPlayerIndex(bootstrap)
 
-	var (
-		target      *fpl.Element
-		suggestions []fpl.MatchSuggestion
-	)
-	if opts.id > 0 {
-		target = findElementByID(bootstrap.Elements, opts.id)
-		if target == nil {
-			return fmt.Errorf("player with ID %d not found in bootstrap data", opts.id)
+	resolver := newPlayerResolver(cmd, opts, ix)
+	if len(refs) > 1 {
+		if opts.live {
+			return errors.New("--live shows a single player; use fpl picks or fpl live for more")
 		}
-	} else {
-		target, suggestions, err = fpl.FindPlayerByName(opts.name, bootstrap.Elements)
-		if err != nil {
-			return err
+		if opts.explain {
+			return errors.New("--explain shows a single player")
 		}
+		return runPlayerBatch(ctx, cmd, opts, client, resolver, refs, columns, sortKeys)
+	}
+
+	target, suggestions, err := resolver.resolve(refs[0])
+	if err != nil {
+		return err
 	}
 
 	summary, err := client.PlayerSummary(ctx, target.ID)
@@ -80,17 +143,152 @@ func runPlayer(ctx context.Context, cmd *cobra.Command, opts *playerOptions) err
 		return err
 	}
 
-	report := buildPlayerReport(target, bootstrap, summary, &opts.gws)
-	if rootOpts.outputJSON {
+	report := buildPlayerReport(target, ix, summary, &opts.gws)
+	if len(suggestions) > 0 {
+		report.Match = buildMatchInfo(refs[0].Name, suggestions, ix)
+	}
+	if opts.live {
+		live, err := loadPlayerLive(ctx, client, ix, target)
+		if err != nil {
+			return err
+		}
+		report.Live = live
+	}
+	if opts.explain {
+		explanation, err := loadPointsExplanation(ctx, client, bootstrap.Events, target.ID, report.Totals.Gameweeks)
+		if err != nil {
+			return err
+		}
+		report.Explain = explanation
+	}
+	sortRows(report.Gameweeks, sortKeys)
+
+	switch outputFormat() {
+	case outputJSON:
 		return printPlayerJSON(cmd, report)
+	case outputNDJSON:
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(report)
+	case outputCSV:
+		return writeCSV(cmd.OutOrStdout(), columns, report.Gameweeks)
+	}
+	return printPlayerTable(cmd, report, columns, opts)
+}
+
+// playerResolver turns player references into elements, sharing the alias
+// store and index across a batch.
+type playerResolver struct {
+	cmd  *cobra.Command
+	opts *playerOptions
+	ix   *fpl.PlayerIndex
+	// store is opened by the first name lookup and stays nil when it cannot
+	// be read.
+	store  *aliases.Store
+	opened bool
+}
+
+func newPlayerResolver(cmd *cobra.Command, opts *playerOptions, ix *fpl.PlayerIndex) *playerResolver {
+	return &playerResolver{cmd: cmd, opts: opts, ix: ix}
+}
+
+// aliases opens the alias store on first use. A store that cannot be read is
+// reported once and names are then matched without aliases.
+func (r *playerResolver) aliases() fpl.AliasSource {
+	if !r.opened {
+		r.opened = true
+		store, err := aliases.Open()
+		if err != nil {
+			fmt.Fprintf(r.cmd.ErrOrStderr(), "warning: ignoring aliases: %v\n", err)
+		}
+		r.store = store
+	}
+	if r.store == nil {
+		return nil
+	}
+	return r.store
+}
+
+func (r *playerResolver) resolve(ref playerRef) (*fpl.Element, []fpl.MatchSuggestion, error) {
+	if ref.ID > 0 {
+		target := r.ix.Player(ref.ID)
+		if target == nil {
+			return nil, nil, fmt.Errorf("player with ID %d not found in bootstrap data", ref.ID)
+		}
+		return target, nil, nil
+	}
+	return r.resolveName(ref.Name)
+}
+
+// resolveName checks user aliases before fuzzy matching. When the match is
+// ambiguous and the user is at a terminal, they are asked to pick and the
+// choice is saved as an alias for the same name; otherwise the best guess is
+// used (or rejected with --strict). Choices narrowed by a team or position
+// are not saved: an alias for the bare name would override the qualifiers
+// on later lookups. Nor are they when the alias store could not be read.
+func (r *playerResolver) resolveName(name string) (*fpl.Element, []fpl.MatchSuggestion, error) {
+	query, err := buildPlayerQuery(name, r.opts, r.ix)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, suggestions, err := r.ix.Find(query, r.aliases())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in, errOut := r.cmd.InOrStdin(), r.cmd.ErrOrStderr()
+	if fpl.IsAmbiguous(suggestions) && canPrompt(in, errOut) {
+		target, err = promptForPlayer(in, errOut, query.Name, suggestions, r.ix)
+		if err != nil {
+			return nil, nil, err
+		}
+		if query.TeamID != 0 || query.ElementTypeID != 0 || r.store == nil {
+			return target, nil, nil
+		}
+		r.store.Set(aliases.Alias{Name: query.Name, Code: target.Code, Player: playerDisplayName(target)})
+		if err := r.store.Save(); err != nil {
+			fmt.Fprintf(errOut, "warning: could not remember choice: %v\n", err)
+		} else {
+			fmt.Fprintf(errOut, "Remembered %q as %s (see fpl alias ls).\n\n", query.Name, playerDisplayName(target))
+		}
+		return target, nil, nil
+	}
+
+	if r.opts.strict {
+		if err := checkStrictMatch(name, suggestions); err != nil {
+			return nil, nil, err
+		}
+	}
+	return target, suggestions, nil
+}
+
+// buildPlayerQuery combines qualifiers embedded in --name with the --team and
+// --position flags.
+func buildPlayerQuery(name string, opts *playerOptions, ix *fpl.PlayerIndex) (fpl.PlayerQuery, error) {
+	query, err := ix.ParseQuery(name)
+	if err != nil {
+		return fpl.PlayerQuery{}, err
 	}
 
-	return printPlayerTable(cmd, report, suggestions, opts.name)
+	var teamID, positionID int
+	if strings.TrimSpace(opts.team) != "" {
+		team, err := ix.ResolveTeam(opts.team)
+		if err != nil {
+			return fpl.PlayerQuery{}, err
+		}
+		teamID = team.ID
+	}
+	if strings.TrimSpace(opts.position) != "" {
+		pos, err := ix.ResolvePosition(opts.position)
+		if err != nil {
+			return fpl.PlayerQuery{}, err
+		}
+		positionID = pos.ID
+	}
+	return query.Merge(teamID, positionID)
 }
 
-func buildPlayerReport(player *fpl.Element, bootstrap *fpl.BootstrapStatic, summary *fpl.PlayerSummary, gw *gwFlag) playerReport {
-	team := findTeam(bootstrap.Teams, player.Team)
-	position := findElementType(bootstrap.ElementTypes, player.ElementType)
+func buildPlayerReport(player *fpl.Element, ix *fpl.PlayerIndex, summary *fpl.PlayerSummary, gw *gwFlag) playerReport {
+	team := ix.Team(player.Team)
+	position := ix.Position(player.ElementType)
 
 	filtered := make([]fpl.HistoryEntry, 0, len(summary.History))
 	for _, entry := range summary.History {
@@ -109,24 +307,32 @@ func buildPlayerReport(player *fpl.Element, bootstrap *fpl.BootstrapStatic, summ
 	for _, entry := range filtered {
 		rows = append(rows, historyRow{
 			Round:       entry.Round,
-			Opponent:    opponentLabel(entry, bootstrap.Teams),
+			Opponent:    opponentLabel(entry, ix),
 			Home:        entry.WasHome,
 			Minutes:     entry.Minutes,
+			Starts:      entry.Starts,
 			Goals:       entry.GoalsScored,
 			Assists:     entry.Assists,
 			CleanSheets: entry.CleanSheets,
+			XG:          parseStat(entry.ExpectedGoals),
+			XA:          parseStat(entry.ExpectedAssists),
+			XGI:         parseStat(entry.ExpectedGoalInvolvements),
 			Points:      entry.TotalPoints,
 		})
 		totals.Gameweeks = append(totals.Gameweeks, entry.Round)
 		totals.Matches++
 		totals.Minutes += entry.Minutes
+		totals.Starts += entry.Starts
 		totals.Goals += entry.GoalsScored
 		totals.Assists += entry.Assists
 		totals.CleanSheets += entry.CleanSheets
 		totals.Points += entry.TotalPoints
 	}
 
+	totals.Derived = deriveMetrics(rows, totals)
+
 	return playerReport{
+		SchemaVersion: playerReportVersion,
 		Player: playerSummaryInfo{
 			ID:          player.ID,
 			Name:        playerDisplayName(player),
@@ -138,6 +344,7 @@ func buildPlayerReport(player *fpl.Element, bootstrap *fpl.BootstrapStatic, summ
 			SelectedBy:  player.SelectedBy,
 			TotalPoints: player.TotalPoints,
 			News:        player.News,
+			Status:      player.Status,
 		},
 		Gameweeks: rows,
 		Totals:    totals,
@@ -151,10 +358,11 @@ func printPlayerJSON(cmd *cobra.Command, report playerReport) error {
 	return enc.Encode(report)
 }
 
-func printPlayerTable(cmd *cobra.Command, report playerReport, suggestions []fpl.MatchSuggestion, requestedName string) error {
+func printPlayerTable(cmd *cobra.Command, report playerReport, columns []historyColumn, opts *playerOptions) error {
 	out := cmd.OutOrStdout()
+	colors := newPalette(out, rootOpts.color)
 	fmt.Fprintf(out, "%s (ID %d) | %s | %s | £%.1f\n",
-		report.Player.Name,
+		colors.bold(report.Player.Name),
 		report.Player.ID,
 		report.Player.Team,
 		report.Player.Position,
@@ -167,23 +375,17 @@ func printPlayerTable(cmd *cobra.Command, report playerReport, suggestions []fpl
 		report.Player.ICTIndex,
 	)
 	if strings.TrimSpace(report.Player.News) != "" {
-		fmt.Fprintf(out, "News: %s\n\n", report.Player.News)
-	}
-
-	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
-	fmt.Fprintln(tw, "GW\tOpponent\tMin\tG\tA\tCS\tPts")
-	for _, row := range report.Gameweeks {
-		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%d\t%d\n",
-			row.Round,
-			row.Opponent,
-			row.Minutes,
-			row.Goals,
-			row.Assists,
-			row.CleanSheets,
-			row.Points,
-		)
+		fmt.Fprintf(out, "News: %s\n\n", colors.news(report.Player.Status, report.Player.News))
+	}
+	if report.Live != nil {
+		printPlayerLive(out, report.Live, colors)
+	}
+
+	visible := fitColumns(columns, report.Gameweeks, terminalWidth(out))
+	renderTable(out, visible, report.Gameweeks, colors)
+	if dropped := droppedHeaders(columns, visible); len(dropped) > 0 {
+		fmt.Fprintln(out, colors.dim(fmt.Sprintf("(hidden to fit terminal: %s)", strings.Join(dropped, ", "))))
 	}
-	tw.Flush()
 
 	if len(report.Gameweeks) > 0 {
 		fmt.Fprintf(out, "\nTotals (GW %s): %d matches | %d pts | %d min | %d G | %d A | %d CS\n",
@@ -195,20 +397,47 @@ func printPlayerTable(cmd *cobra.Command, report playerReport, suggestions []fpl
 			report.Totals.Assists,
 			report.Totals.CleanSheets,
 		)
+		d := report.Totals.Derived
+		fmt.Fprintf(out, "Per 90: %.2f G | %.2f A | %.2f xGI | %.1f pts\n",
+			d.GoalsPer90,
+			d.AssistsPer90,
+			d.XGIPer90,
+			d.PointsPer90,
+		)
+		fmt.Fprintf(out, "Pts/match %.1f | Pts/start %.1f | Avg3 %.1f | Avg5 %.1f | SD %.1f | Haul %.0f%% | Blank %.0f%%\n",
+			d.PointsPerMatch,
+			d.PointsPerStart,
+			d.RollingAvg3,
+			d.RollingAvg5,
+			d.PointsStdDev,
+			d.HaulRate*100,
+			d.BlankRate*100,
+		)
 	} else {
 		fmt.Fprintln(out, "No fixtures recorded for the selected gameweeks.")
 	}
 
-	if shouldSuggestAlternatives(requestedName, suggestions) {
+	if report.Explain != nil && len(report.Explain.Gameweeks) > 0 {
+		printPointsExplanation(out, report.Explain, colors)
+	}
+
+	if opts.chart && len(report.Gameweeks) > 0 {
+		fmt.Fprintln(out)
+		printTrendChart(out, report.Gameweeks, colors)
+	}
+
+	if shouldSuggestAlternatives(report.Match) {
+		if report.Match.Ambiguous {
+			fmt.Fprintln(out, colors.red(fmt.Sprintf("\n%q is ambiguous; showing the best guess (use --strict to fail instead).", report.Match.Query)))
+		}
 		fmt.Fprintln(out, "\nOther close matches:")
-		for _, s := range suggestions {
-			if s.Element == nil {
-				continue
-			}
-			fmt.Fprintf(out, "- %s (alias: %s, distance: %d)\n",
-				playerDisplayName(s.Element),
+		for _, s := range report.Match.Suggestions[1:] {
+			fmt.Fprintf(out, "- %s, %s (ID %d, alias: %s, confidence: %.0f%%)\n",
+				s.Name,
+				s.Team,
+				s.ID,
 				s.Alias,
-				s.Distance,
+				s.Confidence*100,
 			)
 		}
 	}
@@ -216,16 +445,97 @@ func printPlayerTable(cmd *cobra.Command, report playerReport, suggestions []fpl
 	return nil
 }
 
-func shouldSuggestAlternatives(requestedName string, suggestions []fpl.MatchSuggestion) bool {
-	if strings.TrimSpace(requestedName) == "" || len(suggestions) == 0 {
+// loadPlayerLive fetches the current gameweek's live stats and fixtures for
+// one player.
+func loadPlayerLive(ctx context.Context, client *fpl.Client, ix *fpl.PlayerIndex, player *fpl.Element) (*playerLive, error) {
+	gw, err := currentGameweek(ix.Bootstrap().Events)
+	if err != nil {
+		return nil, err
+	}
+	live, err := client.EventLive(ctx, gw)
+	if err != nil {
+		return nil, err
+	}
+	fixtures, err := client.Fixtures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := live.Stats()[player.ID]
+	teamFixtures := fixturesByTeam(fixtures, gw)[player.Team]
+	return &playerLive{
+		Gameweek:         gw,
+		Status:           statusLabel(playStatus(s, teamFixtures), teamFixtures),
+		Minutes:          s.Minutes,
+		Points:           s.TotalPoints,
+		BPS:              s.BPS,
+		Bonus:            s.Bonus,
+		ProvisionalBonus: fpl.ProvisionalBonuses(fixtures, gw)[player.ID],
+	}, nil
+}
+
+func printPlayerLive(out io.Writer, live *playerLive, colors palette) {
+	fmt.Fprintf(out, "Live GW%d (%s): %d pts | %d min | BPS %d | Bonus %d",
+		live.Gameweek, live.Status, live.Points, live.Minutes, live.BPS, live.Bonus)
+	if live.ProvisionalBonus > 0 {
+		fmt.Fprintf(out, " %s", provisionalText(live.ProvisionalBonus))
+	}
+	fmt.Fprintln(out)
+	if live.ProvisionalBonus > 0 {
+		fmt.Fprintln(out, colors.dim(provisionalNote))
+	}
+	fmt.Fprintln(out)
+}
+
+// lowConfidence is the top-match confidence below which alternatives are
+// shown and --strict refuses to guess.
+const lowConfidence = 0.6
+
+func shouldSuggestAlternatives(match *matchInfo) bool {
+	if match == nil || len(match.Suggestions) < 2 {
 		return false
 	}
-	// Inform users when the best match still has a relatively large edit distance.
-	return suggestions[0].Distance > 2 && len(suggestions) > 1
+	return match.Ambiguous || match.Confidence < lowConfidence
 }
 
-func opponentLabel(entry fpl.HistoryEntry, teams []fpl.Team) string {
-	team := findTeam(teams, entry.OpponentTeam)
+func checkStrictMatch(query string, suggestions []fpl.MatchSuggestion) error {
+	if fpl.IsAmbiguous(suggestions) {
+		return &fpl.AmbiguousMatchError{Query: query, Suggestions: suggestions}
+	}
+	if best := suggestions[0]; best.Confidence < lowConfidence {
+		return fmt.Errorf("no confident match for %q: best is %s at %.0f%% confidence", query, best.Alias, best.Confidence*100)
+	}
+	return nil
+}
+
+func buildMatchInfo(query string, suggestions []fpl.MatchSuggestion, ix *fpl.PlayerIndex) *matchInfo {
+	info := &matchInfo{
+		Query:       query,
+		Confidence:  suggestions[0].Confidence,
+		Ambiguous:   fpl.IsAmbiguous(suggestions),
+		Suggestions: make([]matchSuggestionInfo, 0, len(suggestions)),
+	}
+	for _, s := range suggestions {
+		if s.Element == nil {
+			continue
+		}
+		team := "Unknown"
+		if t := ix.Team(s.Element.Team); t != nil {
+			team = t.ShortName
+		}
+		info.Suggestions = append(info.Suggestions, matchSuggestionInfo{
+			ID:         s.Element.ID,
+			Name:       playerDisplayName(s.Element),
+			Team:       team,
+			Alias:      s.Alias,
+			Distance:   s.Distance,
+			Confidence: s.Confidence,
+		})
+	}
+	return info
+}
+
+func opponentLabel(entry fpl.HistoryEntry, ix *fpl.PlayerIndex) string {
+	team := ix.Team(entry.OpponentTeam)
 	label := "Unknown"
 	if team != nil {
 		label = team.ShortName
@@ -266,31 +576,14 @@ func formatGWList(weeks []int) string {
 	return builder.String()
 }
 
-func findElementByID(elements []fpl.Element, id int) *fpl.Element {
-	for i := range elements {
-		if elements[i].ID == id {
-			return &elements[i]
-		}
-	}
-	return nil
-}
-
-func findTeam(teams []fpl.Team, id int) *fpl.Team {
-	for i := range teams {
-		if teams[i].ID == id {
-			return &teams[i]
-		}
-	}
-	return nil
-}
-
-func findElementType(types []fpl.ElementType, id int) *fpl.ElementType {
-	for i := range types {
-		if types[i].ID == id {
-			return &types[i]
-		}
+// parseStat converts the decimal strings the API uses for expected stats,
+// treating missing or malformed values as zero.
+func parseStat(value string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
 	}
-	return nil
+	return f
 }
 
 func teamLabel(team *fpl.Team) string {
@@ -315,10 +608,48 @@ func playerDisplayName(player *fpl.Element) string {
 	return name
 }
 
+// playerReportVersion is the schema_version of playerReport JSON output.
+const playerReportVersion = 1
+
 type playerReport struct {
-	Player    playerSummaryInfo `json:"player"`
-	Gameweeks []historyRow      `json:"gameweeks"`
-	Totals    historyTotals     `json:"totals"`
+	SchemaVersion int               `json:"schema_version"`
+	Player        playerSummaryInfo `json:"player"`
+	Gameweeks     []historyRow      `json:"gameweeks"`
+	Totals        historyTotals     `json:"totals"`
+	// Match is only present for --name lookups.
+	Match *matchInfo `json:"match,omitempty"`
+	// Live is only present with --live and Explain with --explain.
+	Live    *playerLive        `json:"live,omitempty"`
+	Explain *pointsExplanation `json:"explain,omitempty"`
+}
+
+// playerLive is a player's current gameweek so far. Bonus is confirmed;
+// ProvisionalBonus is what live BPS would award in matches whose bonus is
+// not yet confirmed.
+type playerLive struct {
+	Gameweek         int    `json:"gameweek"`
+	Status           string `json:"status"`
+	Minutes          int    `json:"minutes"`
+	Points           int    `json:"points"`
+	BPS              int    `json:"bps"`
+	Bonus            int    `json:"bonus"`
+	ProvisionalBonus int    `json:"provisional_bonus"`
+}
+
+type matchInfo struct {
+	Query       string                `json:"query"`
+	Confidence  float64               `json:"confidence"`
+	Ambiguous   bool                  `json:"ambiguous"`
+	Suggestions []matchSuggestionInfo `json:"suggestions"`
+}
+
+type matchSuggestionInfo struct {
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	Team       string  `json:"team"`
+	Alias      string  `json:"alias"`
+	Distance   int     `json:"distance"`
+	Confidence float64 `json:"confidence"`
 }
 
 type playerSummaryInfo struct {
@@ -332,25 +663,32 @@ type playerSummaryInfo struct {
 	SelectedBy  string  `json:"selected_by_percent"`
 	TotalPoints int     `json:"total_points"`
 	News        string  `json:"news"`
+	Status      string  `json:"status"`
 }
 
 type historyRow struct {
-	Round       int    `json:"round"`
-	Opponent    string `json:"opponent"`
-	Home        bool   `json:"home"`
-	Minutes     int    `json:"minutes"`
-	Goals       int    `json:"goals"`
-	Assists     int    `json:"assists"`
-	CleanSheets int    `json:"clean_sheets"`
-	Points      int    `json:"points"`
+	Round       int     `json:"round"`
+	Opponent    string  `json:"opponent"`
+	Home        bool    `json:"home"`
+	Minutes     int     `json:"minutes"`
+	Starts      int     `json:"starts"`
+	Goals       int     `json:"goals"`
+	Assists     int     `json:"assists"`
+	CleanSheets int     `json:"clean_sheets"`
+	XG          float64 `json:"xg"`
+	XA          float64 `json:"xa"`
+	XGI         float64 `json:"xgi"`
+	Points      int     `json:"points"`
 }
 
 type historyTotals struct {
-	Gameweeks   []int `json:"gameweeks"`
-	Matches     int   `json:"matches"`
-	Minutes     int   `json:"minutes"`
-	Goals       int   `json:"goals"`
-	Assists     int   `json:"assists"`
-	CleanSheets int   `json:"clean_sheets"`
-	Points      int   `json:"points"`
+	Gameweeks   []int          `json:"gameweeks"`
+	Matches     int            `json:"matches"`
+	Minutes     int            `json:"minutes"`
+	Starts      int            `json:"starts"`
+	Goals       int            `json:"goals"`
+	Assists     int            `json:"assists"`
+	CleanSheets int            `json:"clean_sheets"`
+	Points      int            `json:"points"`
+	Derived     derivedMetrics `json:"derived"`
 }
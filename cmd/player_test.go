@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/spf13/cobra"
+)
+
+func TestPlayerResolverIgnoresUnreadableAliases(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	path := filepath.Join(dir, "fpl", "aliases.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("aliases: [unclosed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ix := fpl.NewPlayerIndex(&fpl.BootstrapStatic{Elements: []fpl.Element{
+		{ID: 1, Code: 10, WebName: "Salah", FirstName: "Mohamed", SecondName: "Salah"},
+	}})
+	cmd := &cobra.Command{}
+	var errOut bytes.Buffer
+	cmd.SetErr(&errOut)
+	resolver := newPlayerResolver(cmd, &playerOptions{}, ix)
+
+	// IDs never need the aliases; names fall back to fuzzy matching.
+	for _, ref := range []playerRef{{ID: 1}, {Name: "Salah"}, {Name: "Salah"}} {
+		if el, _, err := resolver.resolve(ref); err != nil || el.ID != 1 {
+			t.Fatalf("resolve %v: got %+v, %v", ref, el, err)
+		}
+	}
+	if got := strings.Count(errOut.String(), "warning: ignoring aliases"); got != 1 {
+		t.Fatalf("expected one warning, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/projection"
+	"github.com/spf13/cobra"
+)
+
+// predictReportVersion is the schema_version of predictReport JSON output.
+const predictReportVersion = 1
+
+// predictPlayerVersion is the schema_version of each fpl predict NDJSON line.
+const predictPlayerVersion = 1
+
+type predictOptions struct {
+	gws      gwFlag
+	players  []string
+	team     string
+	position string
+	maxCost  float64
+	limit    int
+}
+
+type predictReport struct {
+	SchemaVersion int             `json:"schema_version"`
+	Gameweeks     []int           `json:"gameweeks"`
+	Players       []predictPlayer `json:"players"`
+}
+
+type predictPlayer struct {
+	ID        int               `json:"id"`
+	Name      string            `json:"name"`
+	Team      string            `json:"team"`
+	Position  string            `json:"position"`
+	Cost      float64           `json:"cost"`
+	Points    float64           `json:"points"`
+	Minutes   float64           `json:"minutes"`
+	Breakdown pointsBreakdown   `json:"breakdown"`
+	Gameweeks []predictGameweek `json:"gameweeks"`
+}
+
+// predictPlayerLine is one player's projection as streamed with --output
+// ndjson.
+type predictPlayerLine struct {
+	SchemaVersion int `json:"schema_version"`
+	predictPlayer
+}
+
+type predictGameweek struct {
+	Gameweek  int             `json:"gameweek"`
+	Opponents []string        `json:"opponents"`
+	Minutes   float64         `json:"minutes"`
+	Points    float64         `json:"points"`
+	Breakdown pointsBreakdown `json:"breakdown"`
+}
+
+// pointsBreakdown splits expected points by scoring category.
+type pointsBreakdown struct {
+	Appearance  float64 `json:"appearance"`
+	Goals       float64 `json:"goals"`
+	Assists     float64 `json:"assists"`
+	CleanSheets float64 `json:"clean_sheets"`
+	Conceded    float64 `json:"goals_conceded"`
+	Saves       float64 `json:"saves"`
+	Bonus       float64 `json:"bonus"`
+	Cards       float64 `json:"cards"`
+}
+
+func newBreakdown(e projection.Estimate) pointsBreakdown {
+	return pointsBreakdown{
+		Appearance:  roundTo(e.Appearance, 2),
+		Goals:       roundTo(e.Goals, 2),
+		Assists:     roundTo(e.Assists, 2),
+		CleanSheets: roundTo(e.CleanSheets, 2),
+		Conceded:    roundTo(e.Conceded, 2),
+		Saves:       roundTo(e.Saves, 2),
+		Bonus:       roundTo(e.Bonus, 2),
+		Cards:       roundTo(e.Cards, 2),
+	}
+}
+
+func newPredictCmd() *cobra.Command {
+	opts := &predictOptions{}
+	cmd := &cobra.Command{
+		Use:   "predict",
+		Short: "Project expected points for upcoming gameweeks",
+		Long: `Project each player's expected points (xPts) for upcoming gameweeks and list
+the highest.
+
+The model works fixture by fixture from this season's data:
+  minutes       how often the player starts or comes off the bench, and their
+                chance of playing if flagged
+  goals/assists per-90 xG and xA, blended with their position's average and
+                scaled by their team's attack against the opponent's defence
+  clean sheets  Poisson odds from the opponent's attack against their defence
+  saves, bonus  per-90 rates, with saves rising against stronger attacks
+  cards         per-90 yellow and red card rates
+
+Blank gameweeks project nothing and double gameweeks add both fixtures. The
+same model is available to fpl optimize and fpl transfers plan as
+--objective xpts.`,
+		Example: `  fpl predict
+  fpl predict --gw next3 --position MID --max-cost 8
+  fpl predict --gw 20-22 --player Salah --player Palmer
+  fpl predict --gw next5 --team ARS --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPredict(cmd.Context(), cmd, opts)
+		},
+	}
+	cmd.Flags().Var(&opts.gws, "gw", "gameweeks to project: next, nextN, a gameweek or a range (default next)")
+	cmd.Flags().StringArrayVar(&opts.players, "player", nil, "only project this player name or ID (repeatable)")
+	cmd.Flags().StringVar(&opts.team, "team", "", "only project players from this club")
+	cmd.Flags().StringVar(&opts.position, "position", "", "only project players in this position (GKP, DEF, MID or FWD)")
+	cmd.Flags().Float64Var(&opts.maxCost, "max-cost", 0, "only project players costing at most this many £m")
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "number of players to list (0 for all)")
+	cmd.RegisterFlagCompletionFunc("gw", completeUpcomingGameweeks)
+	cmd.RegisterFlagCompletionFunc("team", completeTeams)
+	cmd.RegisterFlagCompletionFunc("position", completePositions)
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newPredictCmd())
+	registerReportSchema(reportSchema{
+		Name:        "predict",
+		Version:     predictReportVersion,
+		Description: "Projected points per player and gameweek from fpl predict --json",
+		Sample:      predictReport{},
+	})
+	registerReportSchema(reportSchema{
+		Name:        "predict-player",
+		Version:     predictPlayerVersion,
+		Description: "One player's projected points per line from fpl predict --output ndjson",
+		Sample:      predictPlayerLine{},
+	})
+}
+
+func runPredict(ctx context.Context, cmd *cobra.Command, opts *predictOptions) error {
+	if opts.limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+
+	next, err := nextGameweek(bootstrap.Events)
+	if err != nil {
+		return err
+	}
+	if len(opts.gws.Ranges()) == 0 && !opts.gws.relative() {
+		opts.gws.next = 1
+	}
+	opts.gws.resolveNext(next)
+	h, err := loadGameweeks(ctx, client, ix, opts.gws.gameweeks(), next)
+	if err != nil {
+		return err
+	}
+
+	keep, err := predictFilter(cmd, opts, ix)
+	if err != nil {
+		return err
+	}
+	model := projection.NewModel(bootstrap, h.Schedule, next)
+	report := predictReport{SchemaVersion: predictReportVersion, Gameweeks: h.Gameweeks}
+	for i := range bootstrap.Elements {
+		el := &bootstrap.Elements[i]
+		if keep(el) {
+			report.Players = append(report.Players, predictElement(el, ix, model, h))
+		}
+	}
+	sort.SliceStable(report.Players, func(i, j int) bool {
+		return report.Players[i].Points > report.Players[j].Points
+	})
+	if opts.limit > 0 && len(report.Players) > opts.limit {
+		report.Players = report.Players[:opts.limit]
+	}
+
+	out := cmd.OutOrStdout()
+	columns := predictColumns(report.Gameweeks)
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, p := range report.Players {
+			if err := enc.Encode(predictPlayerLine{predictPlayerVersion, p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return writeCSV(out, columns, report.Players)
+	}
+	printPredictTable(out, report, columns)
+	return nil
+}
+
+// predictFilter returns which elements to project. Named players are always
+// kept; otherwise players who have left the league are dropped along with
+// any that miss the --team, --position or --max-cost filters.
+func predictFilter(cmd *cobra.Command, opts *predictOptions, ix *fpl.PlayerIndex) (func(*fpl.Element) bool, error) {
+	var teamID, positionID int
+	if strings.TrimSpace(opts.team) != "" {
+		team, err := ix.ResolveTeam(opts.team)
+		if err != nil {
+			return nil, err
+		}
+		teamID = team.ID
+	}
+	if strings.TrimSpace(opts.position) != "" {
+		pos, err := ix.ResolvePosition(opts.position)
+		if err != nil {
+			return nil, err
+		}
+		positionID = pos.ID
+	}
+	maxCost := int(math.Round(opts.maxCost * 10))
+
+	var named map[int]bool
+	if len(opts.players) > 0 {
+		resolver := newPlayerResolver(cmd, &playerOptions{strict: true}, ix)
+		ids, err := resolvePlayerIDs(resolver, opts.players)
+		if err != nil {
+			return nil, fmt.Errorf("--player: %w", err)
+		}
+		named = make(map[int]bool, len(ids))
+		for _, id := range ids {
+			named[id] = true
+		}
+	}
+	return func(el *fpl.Element) bool {
+		switch {
+		case named != nil && !named[el.ID]:
+			return false
+		case named == nil && el.Status == "u":
+			return false
+		case teamID != 0 && el.Team != teamID:
+			return false
+		case positionID != 0 && el.ElementType != positionID:
+			return false
+		case maxCost > 0 && el.NowCost > maxCost:
+			return false
+		}
+		return true
+	}, nil
+}
+
+func predictElement(el *fpl.Element, ix *fpl.PlayerIndex, p projection.Projection, h *horizon) predictPlayer {
+	player := predictPlayer{ID: el.ID, Name: el.WebName, Cost: float64(el.NowCost) / 10}
+	if team := ix.Team(el.Team); team != nil {
+		player.Team = team.ShortName
+	}
+	if pos := ix.Position(el.ElementType); pos != nil {
+		player.Position = pos.SingularNameShort
+	}
+	var total projection.Estimate
+	for _, gw := range h.Gameweeks {
+		est := p.Project(el.ID, gw)
+		week := predictGameweek{
+			Gameweek:  gw,
+			Opponents: []string{},
+			Minutes:   roundTo(est.Minutes, 1),
+			Points:    roundTo(est.Points, 2),
+			Breakdown: newBreakdown(est),
+		}
+		for _, m := range h.Schedule.Matches(el.Team, gw) {
+			week.Opponents = append(week.Opponents, matchLabel(teamShortName(ix, m.Opponent), m.Home))
+		}
+		player.Gameweeks = append(player.Gameweeks, week)
+		total.Add(est)
+	}
+	player.Points = roundTo(total.Points, 2)
+	player.Minutes = roundTo(total.Minutes, 1)
+	player.Breakdown = newBreakdown(total)
+	return player
+}
+
+// matchLabel names an opponent in the usual FPL style: upper case at home
+// and lower case away.
+func matchLabel(opponent string, home bool) string {
+	if home {
+		return strings.ToUpper(opponent) + " (H)"
+	}
+	return strings.ToLower(opponent) + " (A)"
+}
+
+func teamShortName(ix *fpl.PlayerIndex, id int) string {
+	if team := ix.Team(id); team != nil {
+		return team.ShortName
+	}
+	return "???"
+}
+
+func predictColumns(gameweeks []int) []tableColumn[predictPlayer] {
+	columns := []tableColumn[predictPlayer]{
+		{Key: "player", Header: "Player", Priority: 0, Value: func(p predictPlayer) string { return p.Name }},
+		{Key: "team", Header: "Team", Priority: 1, Value: func(p predictPlayer) string { return p.Team }},
+		{Key: "position", Header: "Pos", Priority: 2, Value: func(p predictPlayer) string { return p.Position }},
+		{Key: "cost", Header: "£", Priority: 2, AlignRight: true, Value: func(p predictPlayer) string { return fmt.Sprintf("%.1f", p.Cost) }},
+	}
+	for i, gw := range gameweeks {
+		i := i
+		columns = append(columns, tableColumn[predictPlayer]{
+			Key:        fmt.Sprintf("gw%d", gw),
+			Header:     fmt.Sprintf("GW%d", gw),
+			Priority:   3,
+			AlignRight: true,
+			Value: func(p predictPlayer) string {
+				week := p.Gameweeks[i]
+				if len(week.Opponents) == 0 {
+					return "-"
+				}
+				return fmt.Sprintf("%.1f %s", week.Points, strings.Join(week.Opponents, "+"))
+			},
+		})
+	}
+	return append(columns,
+		intColumn("minutes", "xMins", 4, func(p predictPlayer) int { return int(math.Round(p.Minutes)) }),
+		floatColumn("attack", "Att", 4, func(p predictPlayer) float64 { return p.Breakdown.Goals + p.Breakdown.Assists }),
+		floatColumn("defence", "Def", 4, func(p predictPlayer) float64 {
+			return p.Breakdown.CleanSheets + p.Breakdown.Conceded + p.Breakdown.Saves
+		}),
+		floatColumn("bonus", "Bonus", 5, func(p predictPlayer) float64 { return p.Breakdown.Bonus }),
+		floatColumn("points", "xPts", 0, func(p predictPlayer) float64 { return p.Points }),
+	)
+}
+
+func printPredictTable(out io.Writer, report predictReport, columns []tableColumn[predictPlayer]) {
+	colors := newPalette(out, rootOpts.color)
+	fmt.Fprintf(out, "%s | GW %s\n\n", colors.bold("Projected points"), formatGWList(report.Gameweeks))
+	if len(report.Players) == 0 {
+		fmt.Fprintln(out, "No players match the filters.")
+		return
+	}
+	visible := fitColumns(columns, report.Players, terminalWidth(out))
+	renderTable(out, visible, report.Players, colors)
+	fmt.Fprintln(out, colors.dim("\nAtt = goals + assists, Def = clean sheets + goals conceded + saves; all in expected points."))
+}
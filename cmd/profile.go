@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/lpoulter1/fpl-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// profileListVersion is the schema_version of profileList JSON output.
+const profileListVersion = 1
+
+const (
+	// profileAdminAnnotation marks commands that manage profiles, which must
+	// run even when the selected profile does not exist yet.
+	profileAdminAnnotation = "fpl:profile-admin"
+	// configAdminAnnotation marks commands that manage settings, which must
+	// run even when a setting is invalid so that they can repair it.
+	configAdminAnnotation = "fpl:config-admin"
+)
+
+func managesProfiles(cmd *cobra.Command) bool {
+	return hasAnnotation(cmd, profileAdminAnnotation)
+}
+
+func managesConfig(cmd *cobra.Command) bool {
+	return hasAnnotation(cmd, configAdminAnnotation)
+}
+
+// hasAnnotation reports whether cmd or any parent carries annotation.
+func hasAnnotation(cmd *cobra.Command, annotation string) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[annotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func newProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage named manager profiles",
+		Long: `Manage named profiles, each holding an FPL entry ID plus the leagues and rivals
+that manager follows, so several people can share one machine without
+repeating IDs.
+
+Select a profile per command with --profile, per shell with FPL_PROFILE, or
+save a default with fpl profile use. The active profile's entry, leagues and
+rivals replace the top-level config values; FPL_ENTRY, FPL_LEAGUES and
+FPL_RIVALS still take precedence over it.`,
+		Example: `  fpl profile add alice --entry 123456 --league 314 --rival 654321
+  fpl profile use alice
+  fpl --profile bob config list
+  fpl profile ls`,
+		Annotations: map[string]string{profileAdminAnnotation: ""},
+	}
+	cmd.AddCommand(newProfileAddCmd(), newProfileUseCmd(), newProfileLsCmd(), newProfileRmCmd())
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newProfileCmd())
+	rootCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+	registerReportSchema(reportSchema{
+		Name:        "profile-list",
+		Version:     profileListVersion,
+		Description: "Saved manager profiles and which is active from fpl profile ls --json",
+		Sample:      profileList{},
+	})
+}
+
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Open()
+	if err != nil || len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cfg.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+type profileAddOptions struct {
+	entry   int
+	leagues []int
+	rivals  []int
+	use     bool
+}
+
+func newProfileAddCmd() *cobra.Command {
+	opts := &profileAddOptions{}
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Create a profile or update an existing one",
+		Long: `Create a profile, or update an existing one. When updating, only the flags
+given are changed; pass --league or --rival again to replace those lists.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProfileAdd(cmd, args[0], opts)
+		},
+	}
+	cmd.Flags().IntVar(&opts.entry, "entry", 0, "FPL entry (team) ID; required for new profiles")
+	cmd.Flags().IntSliceVar(&opts.leagues, "league", nil, "classic league ID to follow (repeatable)")
+	cmd.Flags().IntSliceVar(&opts.rivals, "rival", nil, "entry ID of a rival manager (repeatable)")
+	cmd.Flags().BoolVar(&opts.use, "use", false, "also make this the default profile")
+	return cmd
+}
+
+func runProfileAdd(cmd *cobra.Command, name string, opts *profileAddOptions) error {
+	cfg, err := config.Open()
+	if err != nil {
+		return err
+	}
+	p, exists := cfg.Profiles[name]
+	flags := cmd.Flags()
+	if !exists && !flags.Changed("entry") {
+		return errors.New("--entry is required when creating a profile")
+	}
+	if flags.Changed("entry") {
+		if opts.entry <= 0 {
+			return fmt.Errorf("--entry must be positive: %d", opts.entry)
+		}
+		p.Entry = opts.entry
+	}
+	if flags.Changed("league") {
+		if p.Leagues, err = config.CheckIDs(opts.leagues); err != nil {
+			return fmt.Errorf("invalid --league: %w", err)
+		}
+	}
+	if flags.Changed("rival") {
+		if p.Rivals, err = config.CheckIDs(opts.rivals); err != nil {
+			return fmt.Errorf("invalid --rival: %w", err)
+		}
+	}
+	if err := cfg.SetProfile(name, p); err != nil {
+		return err
+	}
+	if opts.use {
+		cfg.Profile = name
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	verb := "Added"
+	if exists {
+		verb = "Updated"
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s profile %s (entry %d)\n", verb, name, p.Entry)
+	return nil
+}
+
+func newProfileUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "use <name>",
+		Short:             "Make a profile the default",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Open()
+			if err != nil {
+				return err
+			}
+			if err := cfg.UseProfile(args[0]); err != nil {
+				return err
+			}
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Using profile %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+type profileEntry struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	config.Profile
+}
+
+type profileList struct {
+	SchemaVersion int            `json:"schema_version"`
+	Path          string         `json:"path"`
+	Profiles      []profileEntry `json:"profiles"`
+}
+
+func newProfileLsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List profiles, marking the active one",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Open()
+			if err != nil {
+				return err
+			}
+			active := cfg.ActiveProfile(rootOpts.profile)
+			entries := make([]profileEntry, 0, len(cfg.Profiles))
+			for _, name := range cfg.ProfileNames() {
+				entries = append(entries, profileEntry{Name: name, Active: name == active, Profile: cfg.Profiles[name]})
+			}
+
+			out := cmd.OutOrStdout()
+			if outputFormat() == outputJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(profileList{SchemaVersion: profileListVersion, Path: cfg.Path(), Profiles: entries})
+			}
+			if len(entries) == 0 {
+				fmt.Fprintf(out, "No profiles defined in %s.\n", cfg.Path())
+				return nil
+			}
+			tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, " \tProfile\tEntry\tLeagues\tRivals")
+			for _, e := range entries {
+				marker := " "
+				if e.Active {
+					marker = "*"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", marker, e.Name, e.Entry, dashIfEmpty(config.FormatIDs(e.Leagues)), dashIfEmpty(config.FormatIDs(e.Rivals)))
+			}
+			return tw.Flush()
+		},
+	}
+}
+
+func newProfileRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "rm <name>",
+		Aliases:           []string{"remove"},
+		Short:             "Delete a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Open()
+			if err != nil {
+				return err
+			}
+			if !cfg.RemoveProfile(args[0]) {
+				return fmt.Errorf("no profile named %q", args[0])
+			}
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed profile %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
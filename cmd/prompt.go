@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+const maxPromptAttempts = 3
+
+// canPrompt reports whether the user can answer an interactive question:
+// stdin must be a terminal and prompts go to stderr so stdout stays clean.
+func canPrompt(in io.Reader, errOut io.Writer) bool {
+	return isTerminal(in) && isTerminal(errOut)
+}
+
+// promptForPlayer lists ranked suggestions and reads the user's pick by
+// number. An empty answer picks the first entry.
+func promptForPlayer(in io.Reader, out io.Writer, query string, suggestions []fpl.MatchSuggestion, ix *fpl.PlayerIndex) (*fpl.Element, error) {
+	fmt.Fprintf(out, "%q matches several players:\n", query)
+	for i, s := range suggestions {
+		team := ix.Team(s.Element.Team)
+		pos := ix.Position(s.Element.ElementType)
+		teamName, posName := "???", "???"
+		if team != nil {
+			teamName = team.ShortName
+		}
+		if pos != nil {
+			posName = pos.SingularNameShort
+		}
+		fmt.Fprintf(out, "  %d) %s  %s  %s  £%.1f  (%.0f%%)\n",
+			i+1,
+			playerDisplayName(s.Element),
+			teamName,
+			posName,
+			float64(s.Element.NowCost)/10.0,
+			s.Confidence*100,
+		)
+	}
+
+	reader := bufio.NewReader(in)
+	for attempt := 0; attempt < maxPromptAttempts; attempt++ {
+		fmt.Fprintf(out, "Pick a player [1-%d] (Enter for 1): ", len(suggestions))
+		line, err := reader.ReadString('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+			return nil, fmt.Errorf("read selection: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return suggestions[0].Element, nil
+		}
+		choice, err := strconv.Atoi(line)
+		if err == nil && choice >= 1 && choice <= len(suggestions) {
+			return suggestions[choice-1].Element, nil
+		}
+		fmt.Fprintf(out, "Invalid choice %q.\n", line)
+	}
+	return nil, errors.New("no player selected")
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestPromptForPlayer(t *testing.T) {
+	bootstrap := &fpl.BootstrapStatic{
+		Elements: []fpl.Element{
+			{ID: 1, WebName: "Gabriel", FirstName: "Gabriel", SecondName: "Magalhães", Team: 1, ElementType: 2},
+			{ID: 2, WebName: "Gabriel", FirstName: "Gabriel", SecondName: "Martinelli", Team: 1, ElementType: 3},
+		},
+		Teams:        []fpl.Team{{ID: 1, Name: "Arsenal", ShortName: "ARS"}},
+		ElementTypes: []fpl.ElementType{{ID: 2, SingularNameShort: "DEF"}, {ID: 3, SingularNameShort: "MID"}},
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+	suggestions := []fpl.MatchSuggestion{
+		{Element: &bootstrap.Elements[0], Confidence: 1},
+		{Element: &bootstrap.Elements[1], Confidence: 1},
+	}
+
+	var out bytes.Buffer
+	picked, err := promptForPlayer(strings.NewReader("x\n2\n"), &out, "Gabriel", suggestions, ix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if picked.ID != 2 {
+		t.Fatalf("expected second player, got %d", picked.ID)
+	}
+	if !strings.Contains(out.String(), "Gabriel Martinelli  ARS  MID") || !strings.Contains(out.String(), `Invalid choice "x"`) {
+		t.Fatalf("unexpected prompt output:\n%s", out.String())
+	}
+
+	picked, err = promptForPlayer(strings.NewReader("\n"), &out, "Gabriel", suggestions, ix)
+	if err != nil || picked.ID != 1 {
+		t.Fatalf("expected Enter to pick the first player, got %+v (%v)", picked, err)
+	}
+
+	if _, err := promptForPlayer(strings.NewReader(""), &out, "Gabriel", suggestions, ix); err == nil {
+		t.Fatal("expected error when input is closed")
+	}
+}
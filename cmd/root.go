@@ -1,19 +1,38 @@
 package cmd
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
 	"github.com/spf13/cobra"
 )
 
 type globalOptions struct {
 	outputJSON bool
+	output     string
 	cacheTTL   time.Duration
+	color      string
+	baseURL    string
+	timezone   string
+
+	profile string
+
+	// entry, leagues and rivals come only from the environment, the active
+	// profile or the config file; commands that take --entry or --league
+	// fall back to them.
+	entry   int
+	leagues []int
+	rivals  []int
 }
 
 var (
 	rootOpts = &globalOptions{
 		cacheTTL: 30 * time.Second,
+		color:    colorAuto,
+		output:   outputTable,
+		baseURL:  fpl.DefaultBaseURL,
+		timezone: "Local",
 	}
 
 	rootCmd = &cobra.Command{
@@ -26,9 +45,25 @@ gameweek-by-gameweek performance in human-friendly tables or machine-friendly JS
 		Version:       "dev",
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := applyConfig(cmd); err != nil {
+				return err
+			}
+			if err := validateOutputFormat(rootOpts.output); err != nil {
+				return err
+			}
+			return validateColorMode(rootOpts.color)
+		},
 	}
 )
 
+const (
+	outputTable  = "table"
+	outputJSON   = "json"
+	outputCSV    = "csv"
+	outputNDJSON = "ndjson"
+)
+
 // Execute runs the root command.
 func Execute() error {
 	return rootCmd.Execute()
@@ -39,7 +74,14 @@ func init() {
 		&rootOpts.outputJSON,
 		"json",
 		false,
-		"print structured JSON output instead of a table",
+		"print structured JSON output instead of a table (shorthand for --output json)",
+	)
+	rootCmd.PersistentFlags().StringVarP(
+		&rootOpts.output,
+		"output",
+		"o",
+		rootOpts.output,
+		"output format: table, json, csv or ndjson",
 	)
 	rootCmd.PersistentFlags().DurationVar(
 		&rootOpts.cacheTTL,
@@ -47,4 +89,55 @@ func init() {
 		rootOpts.cacheTTL,
 		"cache duration for bootstrap-static requests (set to 0 to disable caching)",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&rootOpts.color,
+		"color",
+		rootOpts.color,
+		"colorize table output: auto, always or never (auto honors NO_COLOR and disables color when not a terminal)",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&rootOpts.baseURL,
+		"base-url",
+		rootOpts.baseURL,
+		"FPL API root URL",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&rootOpts.timezone,
+		"timezone",
+		rootOpts.timezone,
+		"IANA time zone for deadlines and kickoff times (e.g. Europe/London)",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&rootOpts.profile,
+		"profile",
+		"",
+		"manager profile supplying the default entry, leagues and rivals (see fpl profile)",
+	)
+}
+
+// newClient builds an API client backed by the on-disk bootstrap cache.
+func newClient() *fpl.Client {
+	client := fpl.NewClient(nil, rootOpts.cacheTTL)
+	client.SetBaseURL(rootOpts.baseURL)
+	if dir, err := fpl.DefaultCacheDir(); err == nil {
+		client.SetCacheDir(dir)
+	}
+	return client
+}
+
+// outputFormat resolves the effective output format, letting --json win for
+// backwards compatibility.
+func outputFormat() string {
+	if rootOpts.outputJSON {
+		return outputJSON
+	}
+	return rootOpts.output
+}
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputTable, outputJSON, outputCSV, outputNDJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid --output value %q: expected table, json, csv or ndjson", format)
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lpoulter1/fpl-cli/internal/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+const schemaBaseURL = "https://github.com/lpoulter1/fpl-cli/schemas"
+
+// reportSchema describes one JSON report type. Bump Version whenever a field
+// is renamed, removed or changes type; adding fields is backwards compatible.
+type reportSchema struct {
+	Name        string
+	Version     int
+	Description string
+	// Sample is a zero value of the report type used for schema generation.
+	Sample any
+}
+
+var reportSchemas = map[string]reportSchema{}
+
+func registerReportSchema(s reportSchema) {
+	reportSchemas[s.Name] = s
+}
+
+func (s reportSchema) document() jsonschema.Schema {
+	doc := jsonschema.Generate(s.Sample)
+	doc["$id"] = fmt.Sprintf("%s/%s/v%d.json", schemaBaseURL, s.Name, s.Version)
+	doc["title"] = s.Name + " report"
+	doc["description"] = s.Description
+	if props, ok := doc["properties"].(jsonschema.Schema); ok {
+		props["schema_version"] = jsonschema.Schema{"type": "integer", "const": s.Version}
+	}
+	return doc
+}
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema [report]",
+		Short: "Print the JSON Schema for a --json report",
+		Long: `Print the JSON Schema document describing a report emitted with --json.
+
+Every JSON report carries a schema_version field. It is bumped whenever a field
+is renamed, removed or changes type, so consumers can validate output and pin
+against a known version. Run without arguments to list the available reports.`,
+		Example: `  fpl schema
+  fpl schema player > player.schema.json`,
+		Args: cobra.MaximumNArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return reportSchemaNames(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return listReportSchemas(cmd)
+			}
+			s, ok := reportSchemas[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown report %q (available: %s)", args[0], strings.Join(reportSchemaNames(), ", "))
+			}
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(s.document())
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(newSchemaCmd())
+}
+
+func reportSchemaNames() []string {
+	names := make([]string, 0, len(reportSchemas))
+	for name := range reportSchemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func listReportSchemas(cmd *cobra.Command) error {
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "Report\tVersion\tDescription")
+	for _, name := range reportSchemaNames() {
+		s := reportSchemas[name]
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", s.Name, s.Version, s.Description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import "github.com/lpoulter1/fpl-cli/internal/fpl"
+
+// squadTypes is a 3-4-3 with a bench of GKP, MID, DEF, FWD, by slot.
+var squadTypes = []int{1, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 1, 3, 2, 4}
+
+// testSquad picks one available team 1 player per slot with the given
+// positions, element IDs matching slots, and captain and vice as the
+// armbands (0 for none). Everyone played 90 minutes for 2 points.
+func testSquad(types []int, captain, vice int) (*fpl.BootstrapStatic, *fpl.EntryPicks, map[int]fpl.LiveStats) {
+	b := &fpl.BootstrapStatic{
+		ElementTypes: []fpl.ElementType{{ID: 1, SingularNameShort: "GKP"}, {ID: 2, SingularNameShort: "DEF"}, {ID: 3, SingularNameShort: "MID"}, {ID: 4, SingularNameShort: "FWD"}},
+	}
+	picks := &fpl.EntryPicks{}
+	stats := make(map[int]fpl.LiveStats)
+	for i, et := range types {
+		id := i + 1
+		b.Elements = append(b.Elements, fpl.Element{ID: id, WebName: "P", Team: 1, ElementType: et, Status: "a"})
+		pick := fpl.Pick{Element: id, Position: id, Multiplier: 1, IsCaptain: id == captain, IsViceCaptain: id == vice}
+		if id == captain {
+			pick.Multiplier = 2
+		} else if id > 11 {
+			pick.Multiplier = 0
+		}
+		picks.Picks = append(picks.Picks, pick)
+		stats[id] = fpl.LiveStats{Minutes: 90, TotalPoints: 2}
+	}
+	return b, picks, stats
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const columnGap = 2
+
+// tableColumn describes one column of a table of R rows. Columns are looked
+// up by Key, which doubles as the CSV header and matches the JSON field name.
+type tableColumn[R any] struct {
+	Key     string
+	Aliases []string
+	Header  string
+	// Priority controls which columns are dropped first when the table is
+	// wider than the terminal: higher values go first, 0 is never dropped.
+	Priority   int
+	AlignRight bool
+	Value      func(row R) string
+	// Number returns the sort value of numeric columns; nil sorts on Value.
+	Number func(row R) float64
+	// Style optionally decorates an already padded cell.
+	Style func(p palette, row R, cell string) string
+}
+
+// fitColumns drops the lowest-priority columns until the table fits in width.
+// A width of 0 means unbounded.
+func fitColumns[R any](columns []tableColumn[R], rows []R, width int) []tableColumn[R] {
+	if width <= 0 {
+		return columns
+	}
+	kept := append([]tableColumn[R](nil), columns...)
+	for tableWidth(kept, rows) > width {
+		drop := -1
+		for i, col := range kept {
+			if col.Priority > 0 && (drop == -1 || col.Priority >= kept[drop].Priority) {
+				drop = i
+			}
+		}
+		if drop == -1 {
+			break
+		}
+		kept = append(kept[:drop], kept[drop+1:]...)
+	}
+	return kept
+}
+
+func columnWidths[R any](columns []tableColumn[R], rows []R) []int {
+	widths := make([]int, len(columns))
+	for i, col := range columns {
+		widths[i] = utf8.RuneCountInString(col.Header)
+		for _, row := range rows {
+			if w := utf8.RuneCountInString(col.Value(row)); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	return widths
+}
+
+func tableWidth[R any](columns []tableColumn[R], rows []R) int {
+	total := 0
+	for _, w := range columnWidths(columns, rows) {
+		total += w
+	}
+	if len(columns) > 1 {
+		total += columnGap * (len(columns) - 1)
+	}
+	return total
+}
+
+// renderTable pads cells on their plain text so ANSI styling never skews alignment.
+func renderTable[R any](out io.Writer, columns []tableColumn[R], rows []R, p palette) {
+	widths := columnWidths(columns, rows)
+	gap := strings.Repeat(" ", columnGap)
+
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = p.bold(pad(col.Header, widths[i], col.AlignRight))
+	}
+	fmt.Fprintln(out, strings.TrimRight(strings.Join(headers, gap), " "))
+
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			cell := pad(col.Value(row), widths[i], col.AlignRight)
+			if col.Style != nil {
+				cell = col.Style(p, row, cell)
+			}
+			cells[i] = cell
+		}
+		fmt.Fprintln(out, strings.TrimRight(strings.Join(cells, gap), " "))
+	}
+}
+
+func pad(text string, width int, right bool) string {
+	fill := width - utf8.RuneCountInString(text)
+	if fill <= 0 {
+		return text
+	}
+	if right {
+		return strings.Repeat(" ", fill) + text
+	}
+	return text + strings.Repeat(" ", fill)
+}
+
+// droppedHeaders lists the headers present in all but missing from kept.
+func droppedHeaders[R any](all, kept []tableColumn[R]) []string {
+	present := make(map[string]struct{}, len(kept))
+	for _, col := range kept {
+		present[col.Header] = struct{}{}
+	}
+	var dropped []string
+	for _, col := range all {
+		if _, ok := present[col.Header]; !ok {
+			dropped = append(dropped, col.Header)
+		}
+	}
+	return dropped
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFitColumnsDropsLowPriorityFirst(t *testing.T) {
+	rows := []historyRow{{Round: 1, Opponent: "ARS (H)", Minutes: 90, Goals: 1, Points: 8}}
+
+	all := fitColumns(historyColumns, rows, 0)
+	if len(all) != len(historyColumns) {
+		t.Fatalf("expected unbounded width to keep all columns, got %d", len(all))
+	}
+
+	narrow := fitColumns(historyColumns, rows, 20)
+	for _, col := range narrow {
+		if col.Header == "CS" {
+			t.Fatalf("expected CS to be dropped first, got %v", droppedHeaders(historyColumns, narrow))
+		}
+	}
+	if narrow[0].Header != "GW" || narrow[len(narrow)-1].Header != "Pts" {
+		t.Fatalf("expected GW and Pts to survive, got %v", narrow)
+	}
+	if tableWidth(narrow, rows) > 20 {
+		t.Fatalf("expected table to fit in 20 columns, got %d", tableWidth(narrow, rows))
+	}
+}
+
+func TestPaletteHonorsModes(t *testing.T) {
+	var buf bytes.Buffer
+	if newPalette(&buf, colorAuto).enabled {
+		t.Fatal("expected auto mode to disable color for non-terminal writers")
+	}
+	if newPalette(&buf, colorNever).points(12, "12") != "12" {
+		t.Fatal("expected never mode to leave text untouched")
+	}
+	if got := newPalette(&buf, colorAlways).points(12, "12"); !strings.Contains(got, "\x1b[") {
+		t.Fatalf("expected always mode to emit ANSI codes, got %q", got)
+	}
+}
+
+func TestRenderTableAlignsStyledCells(t *testing.T) {
+	rows := []historyRow{{Round: 1, Points: 12}, {Round: 10, Points: 2}}
+	var plain, colored bytes.Buffer
+	renderTable(&plain, historyColumns, rows, palette{})
+	renderTable(&colored, historyColumns, rows, palette{enabled: true})
+
+	stripped := ansiPattern.ReplaceAllString(colored.String(), "")
+	if stripped != plain.String() {
+		t.Fatalf("expected colored output to match plain layout:\n%s\nvs\n%s", stripped, plain.String())
+	}
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"github.com/lpoulter1/fpl-cli/internal/optimize"
+	"github.com/spf13/cobra"
+)
+
+// transferPlanVersion is the schema_version of transferPlanReport JSON output.
+const transferPlanVersion = 1
+
+// transferOptionVersion is the schema_version of each fpl transfers plan
+// NDJSON line.
+const transferOptionVersion = 1
+
+type transferPlanOptions struct {
+	objectiveOptions
+	entry         int
+	freeTransfers int
+	maxTransfers  int
+	limit         int
+}
+
+type transferPlanReport struct {
+	SchemaVersion int     `json:"schema_version"`
+	Entry         int     `json:"entry"`
+	EntryName     string  `json:"entry_name"`
+	Objective     string  `json:"objective"`
+	Gameweeks     []int   `json:"gameweeks"`
+	Bank          float64 `json:"bank"`
+	FreeTransfers int     `json:"free_transfers"`
+	// FreeTransfersEstimated is true when free transfers were worked out
+	// from the entry's history rather than given with --free-transfers.
+	FreeTransfersEstimated bool             `json:"free_transfers_estimated"`
+	CurrentScore           float64          `json:"current_score"`
+	Squad                  []squadHolding   `json:"squad"`
+	Options                []transferOption `json:"options"`
+}
+
+type squadHolding struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Team          string  `json:"team"`
+	Position      string  `json:"position"`
+	PurchasePrice float64 `json:"purchase_price"`
+	SellingPrice  float64 `json:"selling_price"`
+	Price         float64 `json:"price"`
+	Score         float64 `json:"score"`
+}
+
+type transferOption struct {
+	Rank      int            `json:"rank"`
+	Transfers []transferPair `json:"transfers"`
+	Hits      int            `json:"hits"`
+	HitCost   int            `json:"hit_cost"`
+	Bank      float64        `json:"bank"`
+	Score     float64        `json:"score"`
+	Gain      float64        `json:"gain"`
+	Net       float64        `json:"net"`
+	Reasons   []string       `json:"reasons"`
+}
+
+// transferOptionLine is one ranked option as streamed with --output ndjson.
+type transferOptionLine struct {
+	SchemaVersion int `json:"schema_version"`
+	transferOption
+}
+
+type transferPair struct {
+	Out transferPlayer `json:"out"`
+	In  transferPlayer `json:"in"`
+}
+
+// transferPlayer is one side of a transfer. Price is the selling price for
+// players going out and the market price for players coming in.
+type transferPlayer struct {
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	Team     string  `json:"team"`
+	Position string  `json:"position"`
+	Price    float64 `json:"price"`
+	Score    float64 `json:"score"`
+}
+
+func newTransfersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfers",
+		Short: "Plan transfers for a manager's squad",
+	}
+	cmd.AddCommand(newTransfersPlanCmd())
+	return cmd
+}
+
+func newTransfersPlanCmd() *cobra.Command {
+	opts := &transferPlanOptions{}
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Rank single and double transfers by projected points gain",
+		Long: `Rank every single transfer, and every pair of transfers, for a manager's
+current squad by projected points gained over the next --horizon gameweeks,
+after any -4 hits.
+
+Squads are valued by their best XI in each gameweek, so a player who blanks is
+benched for that week. Moves respect the bank, each player's selling price
+(half of any rise since they were bought, rounded down) and the three-per-club
+limit. Free transfers are estimated from the entry's history unless given with
+--free-transfers; transfers already made for the next gameweek are not visible
+until its deadline. See fpl optimize --help for the objectives.`,
+		Example: `  fpl transfers plan --entry 123456
+  fpl transfers plan --horizon 5 --objective form
+  fpl transfers plan --free-transfers 2 --limit 5 --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTransfersPlan(cmd.Context(), cmd, opts)
+		},
+	}
+	addEntryFlag(cmd, &opts.entry)
+	opts.register(cmd, 5)
+	cmd.Flags().IntVar(&opts.freeTransfers, "free-transfers", 0, "free transfers available (estimated from history by default)")
+	cmd.Flags().IntVar(&opts.maxTransfers, "max-transfers", 2, "largest number of transfers per option: 1 or 2")
+	cmd.Flags().IntVar(&opts.limit, "limit", 10, "number of options to show")
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newTransfersCmd())
+	registerReportSchema(reportSchema{
+		Name:        "transfers-plan",
+		Version:     transferPlanVersion,
+		Description: "Ranked transfer options from fpl transfers plan --json",
+		Sample:      transferPlanReport{},
+	})
+	registerReportSchema(reportSchema{
+		Name:        "transfers-option",
+		Version:     transferOptionVersion,
+		Description: "One ranked transfer option per line from fpl transfers plan --output ndjson",
+		Sample:      transferOptionLine{},
+	})
+}
+
+func runTransfersPlan(ctx context.Context, cmd *cobra.Command, opts *transferPlanOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if opts.freeTransfers < 0 || opts.freeTransfers > fpl.MaxFreeTransfers {
+		return fmt.Errorf("--free-transfers must be between 0 and %d", fpl.MaxFreeTransfers)
+	}
+	if opts.limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	entryID, err := resolveEntry(cmd, opts.entry)
+	if err != nil {
+		return err
+	}
+
+	client := newClient()
+	bootstrap, err := client.Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+	ix := fpl.NewPlayerIndex(bootstrap)
+
+	squad, err := loadManagerSquad(ctx, client, ix, entryID)
+	if err != nil {
+		return err
+	}
+	free, estimated := opts.freeTransfers, !cmd.Flags().Changed("free-transfers")
+	if estimated {
+		free = fpl.FreeTransfers(squad.History)
+	}
+	perGW, h, err := opts.project(ctx, client, ix)
+	if err != nil {
+		return err
+	}
+	totals := totalScores(perGW)
+
+	current := optimize.Squad{Bank: squad.Bank, FreeTransfers: free}
+	for _, p := range squad.Picks.Picks {
+		el := ix.Player(p.Element)
+		if el == nil {
+			return fmt.Errorf("player %d from entry %d is missing from bootstrap data", p.Element, entryID)
+		}
+		current.Players = append(current.Players, optimize.Candidate{
+			ID:       el.ID,
+			Team:     el.Team,
+			Position: el.ElementType,
+			Cost:     squad.Selling[el.ID],
+			Score:    totals[el.ID],
+		})
+	}
+	rules := optimize.DefaultRules()
+	rules.BenchWeight = opts.benchWeight
+	moves, base, err := optimize.PlanTransfers(current, squadCandidates(bootstrap.Elements, totals, nil), perGW, rules, optimize.TransferOptions{
+		MaxTransfers: opts.maxTransfers,
+		HitCost:      fpl.TransferHitCost,
+		Limit:        opts.limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	report := transferPlanReport{
+		SchemaVersion:          transferPlanVersion,
+		Entry:                  entryID,
+		EntryName:              squad.Entry.Name,
+		Objective:              opts.objective,
+		Gameweeks:              h.gameweeks(),
+		Bank:                   float64(squad.Bank) / 10,
+		FreeTransfers:          free,
+		FreeTransfersEstimated: estimated,
+		CurrentScore:           roundTo(base, 2),
+		Squad:                  make([]squadHolding, 0, len(current.Players)),
+		Options:                make([]transferOption, 0, len(moves)),
+	}
+	for _, c := range current.Players {
+		tp := newTransferPlayer(ix, c)
+		report.Squad = append(report.Squad, squadHolding{
+			ID:            tp.ID,
+			Name:          tp.Name,
+			Team:          tp.Team,
+			Position:      tp.Position,
+			PurchasePrice: float64(squad.Purchase[c.ID]) / 10,
+			SellingPrice:  tp.Price,
+			Price:         float64(ix.Player(c.ID).NowCost) / 10,
+			Score:         tp.Score,
+		})
+	}
+	for i, m := range moves {
+		report.Options = append(report.Options, newTransferOption(i+1, m, ix, h, free))
+	}
+
+	out := cmd.OutOrStdout()
+	switch outputFormat() {
+	case outputJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case outputNDJSON:
+		enc := json.NewEncoder(out)
+		for _, o := range report.Options {
+			if err := enc.Encode(transferOptionLine{transferOptionVersion, o}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputCSV:
+		return writeCSV(out, transferCSVColumns, report.Options)
+	}
+	printTransferPlan(out, report)
+	return nil
+}
+
+func newTransferPlayer(ix *fpl.PlayerIndex, c optimize.Candidate) transferPlayer {
+	tp := transferPlayer{ID: c.ID, Price: float64(c.Cost) / 10, Score: roundTo(c.Score, 2)}
+	if el := ix.Player(c.ID); el != nil {
+		tp.Name = el.WebName
+	}
+	if team := ix.Team(c.Team); team != nil {
+		tp.Team = team.ShortName
+	}
+	if pos := ix.Position(c.Position); pos != nil {
+		tp.Position = pos.SingularNameShort
+	}
+	return tp
+}
+
+func newTransferOption(rank int, m optimize.Move, ix *fpl.PlayerIndex, h *horizon, free int) transferOption {
+	opt := transferOption{
+		Rank:    rank,
+		Hits:    m.Hits,
+		HitCost: m.Hits * fpl.TransferHitCost,
+		Bank:    float64(m.Bank) / 10,
+		Score:   roundTo(m.Score, 2),
+		Gain:    roundTo(m.Gain, 2),
+		Net:     roundTo(m.Net, 2),
+	}
+	for i := range m.Out {
+		pair := transferPair{Out: newTransferPlayer(ix, m.Out[i]), In: newTransferPlayer(ix, m.In[i])}
+		opt.Transfers = append(opt.Transfers, pair)
+		opt.Reasons = append(opt.Reasons, pairReason(pair, ix, h))
+	}
+
+	var cost string
+	switch {
+	case m.Hits == 0:
+		cost = fmt.Sprintf("Uses %d of %d free transfers", len(m.Out), free)
+	case m.Net > 0:
+		cost = fmt.Sprintf("The -%d hit is outweighed by %+.1f projected", opt.HitCost, m.Gain)
+	default:
+		cost = fmt.Sprintf("The -%d hit is not earned back (%+.1f projected)", opt.HitCost, m.Gain)
+	}
+	opt.Reasons = append(opt.Reasons, fmt.Sprintf("%s, leaving £%.1fm in the bank.", cost, opt.Bank))
+	return opt
+}
+
+// pairReason explains one swap: projected points each way, blank and double
+// gameweeks in the horizon, and any injury or suspension news.
+func pairReason(pair transferPair, ix *fpl.PlayerIndex, h *horizon) string {
+	parts := []string{fmt.Sprintf("%s → %s: %.1f → %.1f pts", pair.Out.Name, pair.In.Name, pair.Out.Score, pair.In.Score)}
+	for _, p := range []transferPlayer{pair.Out, pair.In} {
+		el := ix.Player(p.ID)
+		if el == nil {
+			continue
+		}
+		if h != nil {
+			for _, gw := range h.Gameweeks {
+				switch n := h.count(el.Team, gw); {
+				case n == 0:
+					parts = append(parts, fmt.Sprintf("%s blanks in GW%d", p.Name, gw))
+				case n == 2:
+					parts = append(parts, fmt.Sprintf("%s plays twice in GW%d", p.Name, gw))
+				case n > 2:
+					parts = append(parts, fmt.Sprintf("%s plays %d times in GW%d", p.Name, n, gw))
+				}
+			}
+		}
+		if el.Status != "a" && el.News != "" {
+			parts = append(parts, fmt.Sprintf("%s: %s", p.Name, el.News))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func reasonText(o transferOption) string {
+	return strings.Join(o.Reasons, ". ")
+}
+
+func transferNames(o transferOption, side func(transferPair) transferPlayer) string {
+	names := make([]string, len(o.Transfers))
+	for i, t := range o.Transfers {
+		names[i] = side(t).Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func transferCount(o transferOption) string {
+	if o.Hits == 0 {
+		return fmt.Sprintf("%d free", len(o.Transfers))
+	}
+	return fmt.Sprintf("%d (-%d)", len(o.Transfers), o.HitCost)
+}
+
+var transferColumns = []tableColumn[transferOption]{
+	intColumn("rank", "#", 0, func(o transferOption) int { return o.Rank }),
+	{Key: "out", Header: "Out", Priority: 0, Value: func(o transferOption) string {
+		return transferNames(o, func(t transferPair) transferPlayer { return t.Out })
+	}},
+	{Key: "in", Header: "In", Priority: 0, Value: func(o transferOption) string {
+		return transferNames(o, func(t transferPair) transferPlayer { return t.In })
+	}},
+	{Key: "transfers", Header: "Transfers", Priority: 1, Value: transferCount},
+	{Key: "gain", Header: "Gain", Priority: 2, AlignRight: true, Value: func(o transferOption) string { return fmt.Sprintf("%+.2f", o.Gain) }},
+	{Key: "net", Header: "Net", Priority: 0, AlignRight: true, Value: func(o transferOption) string { return fmt.Sprintf("%+.2f", o.Net) }},
+	{Key: "bank", Header: "Bank", Priority: 1, AlignRight: true, Value: func(o transferOption) string { return fmt.Sprintf("£%.1fm", o.Bank) }},
+}
+
+var transferCSVColumns = append(transferColumns[:len(transferColumns):len(transferColumns)],
+	tableColumn[transferOption]{Key: "reasons", Header: "Reasons", Value: func(o transferOption) string { return reasonText(o) }},
+)
+
+func printTransferPlan(out io.Writer, report transferPlanReport) {
+	colors := newPalette(out, rootOpts.color)
+	span := "custom projection"
+	if len(report.Gameweeks) > 0 {
+		span = "GW " + formatGWList(report.Gameweeks)
+	}
+	freeNote := ""
+	if report.FreeTransfersEstimated {
+		freeNote = " (estimated)"
+	}
+	fmt.Fprintf(out, "%s | %s | %s | %d free transfers%s | bank £%.1fm\n",
+		colors.bold(fmt.Sprintf("Transfer plan for %s (entry %d)", report.EntryName, report.Entry)),
+		span,
+		report.Objective,
+		report.FreeTransfers,
+		freeNote,
+		report.Bank,
+	)
+	fmt.Fprintf(out, "Current squad projects %.2f points.\n\n", report.CurrentScore)
+
+	if len(report.Options) == 0 {
+		fmt.Fprintln(out, "No legal transfer fits the bank and club limit.")
+		return
+	}
+	visible := fitColumns(transferColumns, report.Options, terminalWidth(out))
+	renderTable(out, visible, report.Options, colors)
+
+	fmt.Fprintln(out)
+	for _, o := range report.Options {
+		fmt.Fprintf(out, "%d. %s\n", o.Rank, reasonText(o))
+	}
+	if report.Options[0].Net <= 0 {
+		rolled := min(report.FreeTransfers+1, fpl.MaxFreeTransfers)
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, colors.dim(fmt.Sprintf("No transfer gains points; rolling leaves %d free transfers next week.", rolled)))
+	}
+}
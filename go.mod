@@ -5,10 +5,13 @@ go 1.23.12
 require (
 	github.com/lithammer/fuzzysearch v1.1.8
 	github.com/spf13/cobra v1.10.1
+	github.com/spf13/pflag v1.0.9
+	golang.org/x/term v0.32.0
+	golang.org/x/text v0.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.9 // indirect
-	golang.org/x/text v0.9.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
 )
@@ -0,0 +1,129 @@
+// Package aliases persists user-chosen names for players, keyed on the
+// element code so entries survive the yearly reassignment of player IDs.
+package aliases
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"gopkg.in/yaml.v3"
+)
+
+// Alias maps a name as typed by the user to a player.
+type Alias struct {
+	Name string `yaml:"name" json:"name"`
+	Code int    `yaml:"code" json:"code"`
+	// Player is a human-readable note of who the code referred to when the
+	// alias was saved; lookups only use Code.
+	Player string `yaml:"player,omitempty" json:"player,omitempty"`
+}
+
+// Store is an aliases file loaded into memory.
+type Store struct {
+	path    string
+	Aliases []Alias `yaml:"aliases"`
+}
+
+// DefaultPath returns the aliases file location under the user config
+// directory, e.g. ~/.config/fpl/aliases.yaml.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "fpl", "aliases.yaml"), nil
+}
+
+// Open loads the store at DefaultPath.
+func Open() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Path returns the file the store reads from and saves to.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the store at path. A missing file yields an empty store.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Lookup returns the alias matching name, comparing with fpl.NormalizeName.
+func (s *Store) Lookup(name string) (Alias, bool) {
+	key := fpl.NormalizeName(name)
+	if key == "" {
+		return Alias{}, false
+	}
+	for _, a := range s.Aliases {
+		if fpl.NormalizeName(a.Name) == key {
+			return a, true
+		}
+	}
+	return Alias{}, false
+}
+
+// LookupCode implements fpl.AliasSource.
+func (s *Store) LookupCode(name string) (int, bool) {
+	a, ok := s.Lookup(name)
+	return a.Code, ok
+}
+
+// Remove deletes the alias matching name and reports whether one existed.
+func (s *Store) Remove(name string) bool {
+	key := fpl.NormalizeName(name)
+	for i := range s.Aliases {
+		if fpl.NormalizeName(s.Aliases[i].Name) == key {
+			s.Aliases = append(s.Aliases[:i], s.Aliases[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Set adds or replaces the alias for a.Name.
+func (s *Store) Set(a Alias) {
+	key := fpl.NormalizeName(a.Name)
+	for i := range s.Aliases {
+		if fpl.NormalizeName(s.Aliases[i].Name) == key {
+			s.Aliases[i] = a
+			return
+		}
+	}
+	s.Aliases = append(s.Aliases, a)
+	sort.Slice(s.Aliases, func(i, j int) bool {
+		return fpl.NormalizeName(s.Aliases[i].Name) < fpl.NormalizeName(s.Aliases[j].Name)
+	})
+}
+
+// Save writes the store back to its path, creating parent directories.
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
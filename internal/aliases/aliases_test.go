@@ -0,0 +1,39 @@
+package aliases
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fpl", "aliases.yaml")
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading missing file: %v", err)
+	}
+	if _, ok := store.Lookup("Gabriel"); ok {
+		t.Fatal("expected empty store")
+	}
+
+	store.Set(Alias{Name: "Gabriel", Code: 226597, Player: "Gabriel dos Santos Magalhães"})
+	store.Set(Alias{Name: "KDB", Code: 61366})
+	store.Set(Alias{Name: "gabriel", Code: 205651})
+	if err := store.Save(); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reloaded.Aliases) != 2 {
+		t.Fatalf("expected replaced alias to be deduplicated, got %+v", reloaded.Aliases)
+	}
+	if a, ok := reloaded.Lookup(" GABRIEL "); !ok || a.Code != 205651 {
+		t.Fatalf("expected normalized lookup to find replaced alias, got %+v (%v)", a, ok)
+	}
+	if a, ok := reloaded.Lookup("kdb"); !ok || a.Code != 61366 {
+		t.Fatalf("expected kdb alias, got %+v (%v)", a, ok)
+	}
+}
@@ -0,0 +1,415 @@
+// Package config loads user defaults from config.yaml and FPL_* environment
+// variables. Command-line flags take precedence over both and are applied by
+// the cmd package.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+	"gopkg.in/yaml.v3"
+)
+
+// PathEnv overrides the config file location.
+const PathEnv = "FPL_CONFIG"
+
+// Config is the contents of config.yaml. Empty fields are unset.
+type Config struct {
+	path     string
+	Output   string `yaml:"output,omitempty"`
+	CacheTTL string `yaml:"cache_ttl,omitempty"`
+	BaseURL  string `yaml:"base_url,omitempty"`
+	Timezone string `yaml:"timezone,omitempty"`
+	Entry    int    `yaml:"entry,omitempty"`
+	Leagues  []int  `yaml:"leagues,omitempty"`
+	Rivals   []int  `yaml:"rivals,omitempty"`
+
+	// Profile names the profile used when neither --profile nor FPL_PROFILE
+	// is given.
+	Profile  string             `yaml:"profile,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+
+	warnings []Warning
+}
+
+// Warning is a setting that was ignored because its value is invalid.
+type Warning struct {
+	Key    string
+	Source Source
+	Err    error
+}
+
+func (w Warning) Error() string {
+	return fmt.Sprintf("%v; ignoring it", w.Err)
+}
+
+// Source says where an effective setting came from.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceProfile Source = "profile"
+	SourceEnv     Source = "env"
+)
+
+// Key describes one setting.
+type Key struct {
+	Name        string
+	Env         string
+	Default     string
+	Description string
+
+	get   func(c *Config) string
+	set   func(c *Config, value string) error
+	reset func(c *Config)
+}
+
+// Keys lists every setting in display order.
+var Keys = []Key{
+	{
+		Name:        "output",
+		Env:         "FPL_OUTPUT",
+		Default:     "table",
+		Description: "default output format: table, json, csv or ndjson",
+		reset:       func(c *Config) { c.Output = "" },
+		get:         func(c *Config) string { return c.Output },
+		set: func(c *Config, v string) error {
+			switch v {
+			case "table", "json", "csv", "ndjson":
+				c.Output = v
+				return nil
+			}
+			return fmt.Errorf("invalid output %q: expected table, json, csv or ndjson", v)
+		},
+	},
+	{
+		Name:        "cache_ttl",
+		Env:         "FPL_CACHE_TTL",
+		Default:     "30s",
+		Description: "how long cached bootstrap data stays fresh (0 disables caching)",
+		reset:       func(c *Config) { c.CacheTTL = "" },
+		get:         func(c *Config) string { return c.CacheTTL },
+		set: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil || d < 0 {
+				return fmt.Errorf("invalid cache_ttl %q: expected a duration such as 30s or 10m", v)
+			}
+			c.CacheTTL = v
+			return nil
+		},
+	},
+	{
+		Name:        "base_url",
+		Env:         "FPL_BASE_URL",
+		Default:     fpl.DefaultBaseURL,
+		Description: "FPL API root",
+		reset:       func(c *Config) { c.BaseURL = "" },
+		get:         func(c *Config) string { return c.BaseURL },
+		set: func(c *Config, v string) error {
+			u, err := url.Parse(v)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid base_url %q: expected an http or https URL", v)
+			}
+			c.BaseURL = v
+			return nil
+		},
+	},
+	{
+		Name:        "timezone",
+		Env:         "FPL_TIMEZONE",
+		Default:     "Local",
+		Description: "IANA time zone for deadlines and kickoffs, e.g. Europe/London",
+		reset:       func(c *Config) { c.Timezone = "" },
+		get:         func(c *Config) string { return c.Timezone },
+		set: func(c *Config, v string) error {
+			if _, err := time.LoadLocation(v); err != nil {
+				return fmt.Errorf("invalid timezone %q: %w", v, err)
+			}
+			c.Timezone = v
+			return nil
+		},
+	},
+	{
+		Name:        "entry",
+		Env:         "FPL_ENTRY",
+		Description: "default manager entry (team) ID",
+		reset:       func(c *Config) { c.Entry = 0 },
+		get: func(c *Config) string {
+			if c.Entry == 0 {
+				return ""
+			}
+			return strconv.Itoa(c.Entry)
+		},
+		set: func(c *Config, v string) error {
+			id, err := strconv.Atoi(v)
+			if err != nil || id <= 0 {
+				return fmt.Errorf("invalid entry %q: expected a positive ID", v)
+			}
+			c.Entry = id
+			return nil
+		},
+	},
+	{
+		Name:        "leagues",
+		Env:         "FPL_LEAGUES",
+		Description: "comma-separated classic league IDs",
+		reset:       func(c *Config) { c.Leagues = nil },
+		get: func(c *Config) string {
+			return FormatIDs(c.Leagues)
+		},
+		set: func(c *Config, v string) error {
+			ids, err := ParseIDs(v)
+			if err != nil {
+				return fmt.Errorf("invalid leagues %q: %w", v, err)
+			}
+			c.Leagues = ids
+			return nil
+		},
+	},
+	{
+		Name:        "rivals",
+		Env:         "FPL_RIVALS",
+		Description: "comma-separated entry IDs of rival managers",
+		reset:       func(c *Config) { c.Rivals = nil },
+		get: func(c *Config) string {
+			return FormatIDs(c.Rivals)
+		},
+		set: func(c *Config, v string) error {
+			ids, err := ParseIDs(v)
+			if err != nil {
+				return fmt.Errorf("invalid rivals %q: %w", v, err)
+			}
+			c.Rivals = ids
+			return nil
+		},
+	},
+}
+
+// LookupKey returns the setting called name.
+func LookupKey(name string) (Key, bool) {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// KeyNames returns every setting name in display order.
+func KeyNames() []string {
+	names := make([]string, len(Keys))
+	for i, k := range Keys {
+		names[i] = k.Name
+	}
+	return names
+}
+
+func lookup(name string) (Key, error) {
+	k, ok := LookupKey(name)
+	if !ok {
+		return Key{}, fmt.Errorf("unknown config key %q (available: %s)", name, strings.Join(KeyNames(), ", "))
+	}
+	return k, nil
+}
+
+// DefaultPath returns $FPL_CONFIG, or config.yaml under the user config
+// directory, e.g. ~/.config/fpl/config.yaml.
+func DefaultPath() (string, error) {
+	if path := os.Getenv(PathEnv); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "fpl", "config.yaml"), nil
+}
+
+// Open loads the config at DefaultPath.
+func Open() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Load reads the config at path. A missing file yields an empty config.
+// Invalid values are dropped one key at a time and reported by Warnings, so
+// one bad setting never makes the whole file unusable.
+func Load(path string) (*Config, error) {
+	c := &Config{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for _, k := range Keys {
+		if v := k.get(c); v != "" {
+			if err := k.set(&Config{}, v); err != nil {
+				k.reset(c)
+				c.warnings = append(c.warnings, Warning{Key: k.Name, Source: SourceFile, Err: fmt.Errorf("%s: %w", path, err)})
+			}
+		}
+	}
+	return c, nil
+}
+
+// Warnings lists the settings that were ignored as invalid: file values
+// from Load and, on an effective config, environment variables too.
+func (c *Config) Warnings() []Warning {
+	return c.warnings
+}
+
+// Path returns the file the config reads from and saves to.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Get returns the file value of a setting, or "" when unset.
+func (c *Config) Get(name string) (string, error) {
+	k, err := lookup(name)
+	if err != nil {
+		return "", err
+	}
+	return k.get(c), nil
+}
+
+// Set validates and stores a setting. Call Save to persist it.
+func (c *Config) Set(name, value string) error {
+	k, err := lookup(name)
+	if err != nil {
+		return err
+	}
+	return k.set(c, strings.TrimSpace(value))
+}
+
+// Unset clears a setting so the default applies again.
+func (c *Config) Unset(name string) error {
+	k, err := lookup(name)
+	if err != nil {
+		return err
+	}
+	k.reset(c)
+	return nil
+}
+
+// Effective layers the named profile (if any) and then FPL_* environment
+// variables over the file values, and reports where each setting came from.
+// Settings found nowhere are left empty with SourceDefault. Invalid
+// environment values are skipped and added to the effective config's
+// Warnings.
+func (c *Config) Effective(profile string) (*Config, map[string]Source, error) {
+	eff := *c
+	eff.warnings = append([]Warning(nil), c.warnings...)
+	sources := make(map[string]Source, len(Keys))
+	for _, k := range Keys {
+		sources[k.Name] = SourceDefault
+		if k.get(c) != "" {
+			sources[k.Name] = SourceFile
+		}
+	}
+	if profile != "" {
+		p, err := c.LookupProfile(profile)
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, set := range p.settings() {
+			set(&eff)
+			sources[key] = SourceProfile
+		}
+	}
+	for _, k := range Keys {
+		v, ok := os.LookupEnv(k.Env)
+		if !ok || strings.TrimSpace(v) == "" {
+			continue
+		}
+		if err := k.set(&eff, strings.TrimSpace(v)); err != nil {
+			eff.warnings = append(eff.warnings, Warning{Key: k.Name, Source: SourceEnv, Err: fmt.Errorf("%s: %w", k.Env, err)})
+			continue
+		}
+		sources[k.Name] = SourceEnv
+	}
+	return &eff, sources, nil
+}
+
+// Value returns the effective value of k in c, falling back to its default.
+func (k Key) Value(c *Config) string {
+	if v := k.get(c); v != "" {
+		return v
+	}
+	return k.Default
+}
+
+// Save writes the config back to its path, creating parent directories.
+func (c *Config) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o644)
+}
+
+// ParseIDs parses a comma-separated list of positive IDs, dropping duplicates.
+func ParseIDs(value string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a positive ID", part)
+		}
+		ids = append(ids, id)
+	}
+	return CheckIDs(ids)
+}
+
+// CheckIDs rejects non-positive IDs and an empty list, and drops duplicates.
+func CheckIDs(ids []int) ([]int, error) {
+	var out []int
+	seen := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("%d is not a positive ID", id)
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("at least one ID is required")
+	}
+	return out, nil
+}
+
+// FormatIDs joins ids with commas.
+func FormatIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
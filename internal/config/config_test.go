@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fpl", "config.yaml")
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("load missing file: %v", err)
+	}
+	for key, value := range map[string]string{"output": "csv", "cache-ttl": "5m", "entry": "1234", "leagues": "10, 20,10"} {
+		if err := c.Set(key, value); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+	if err := c.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if loaded.Output != "csv" || loaded.CacheTTL != "5m" || loaded.Entry != 1234 || !reflect.DeepEqual(loaded.Leagues, []int{10, 20}) {
+		t.Fatalf("unexpected config after reload: %+v", loaded)
+	}
+	if v, _ := loaded.Get("leagues"); v != "10,20" {
+		t.Fatalf("expected formatted leagues, got %q", v)
+	}
+
+	if err := loaded.Unset("entry"); err != nil || loaded.Entry != 0 || loaded.Output != "csv" {
+		t.Fatalf("unset should clear only entry: %+v (%v)", loaded, err)
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	c := &Config{}
+	for key, value := range map[string]string{
+		"output":    "yaml",
+		"cache_ttl": "soon",
+		"base_url":  "ftp://example.com",
+		"timezone":  "Mars/Olympus",
+		"entry":     "-1",
+		"leagues":   "1,x",
+		"colour":    "auto",
+	} {
+		if err := c.Set(key, value); err == nil {
+			t.Errorf("expected %s=%q to be rejected", key, value)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("cache_ttl: forever\noutput: csv\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("an invalid value should not fail loading: %v", err)
+	}
+	if loaded.CacheTTL != "" || loaded.Output != "csv" {
+		t.Fatalf("expected only the invalid value to be dropped, got %+v", loaded)
+	}
+	if w := loaded.Warnings(); len(w) != 1 || w[0].Key != "cache_ttl" || w[0].Source != SourceFile {
+		t.Fatalf("expected one cache_ttl warning, got %v", w)
+	}
+}
+
+func TestEffectivePrecedence(t *testing.T) {
+	c := &Config{Output: "csv", CacheTTL: "1m"}
+	t.Setenv("FPL_OUTPUT", "json")
+	t.Setenv("FPL_CACHE_TTL", "")
+
+	eff, sources, err := c.Effective("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eff.Output != "json" || sources["output"] != SourceEnv {
+		t.Fatalf("expected env to beat file, got %q from %s", eff.Output, sources["output"])
+	}
+	if eff.CacheTTL != "1m" || sources["cache_ttl"] != SourceFile {
+		t.Fatalf("expected empty env to fall through to file, got %q from %s", eff.CacheTTL, sources["cache_ttl"])
+	}
+	if sources["timezone"] != SourceDefault {
+		t.Fatalf("expected default timezone source, got %s", sources["timezone"])
+	}
+	if c.Output != "csv" {
+		t.Fatal("Effective must not modify the file config")
+	}
+
+	t.Setenv("FPL_ENTRY", "abc")
+	eff, sources, err = c.Effective("")
+	if err != nil {
+		t.Fatalf("an invalid environment value should be skipped: %v", err)
+	}
+	if sources["entry"] != SourceDefault || eff.Output != "json" {
+		t.Fatalf("expected FPL_ENTRY to be skipped and the rest applied, got %s and %q", sources["entry"], eff.Output)
+	}
+	if w := eff.Warnings(); len(w) != 1 || w[0].Key != "entry" || w[0].Source != SourceEnv {
+		t.Fatalf("expected one FPL_ENTRY warning, got %v", w)
+	}
+	if len(c.Warnings()) != 0 {
+		t.Fatal("Effective must not add warnings to the file config")
+	}
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ProfileEnv selects a profile when --profile is not given.
+const ProfileEnv = "FPL_PROFILE"
+
+var profileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Profile is a named manager identity: an FPL entry plus the leagues and
+// rivals it follows. Fields it leaves empty fall through to the top-level
+// settings.
+type Profile struct {
+	Entry   int   `yaml:"entry,omitempty" json:"entry,omitempty"`
+	Leagues []int `yaml:"leagues,omitempty" json:"leagues,omitempty"`
+	Rivals  []int `yaml:"rivals,omitempty" json:"rivals,omitempty"`
+}
+
+// settings returns a setter for each field the profile defines, keyed by
+// config key.
+func (p Profile) settings() map[string]func(*Config) {
+	out := make(map[string]func(*Config))
+	if p.Entry != 0 {
+		out["entry"] = func(c *Config) { c.Entry = p.Entry }
+	}
+	if len(p.Leagues) > 0 {
+		out["leagues"] = func(c *Config) { c.Leagues = p.Leagues }
+	}
+	if len(p.Rivals) > 0 {
+		out["rivals"] = func(c *Config) { c.Rivals = p.Rivals }
+	}
+	return out
+}
+
+// ActiveProfile returns override when set, else $FPL_PROFILE, else the
+// saved default. An empty result means no profile is in use.
+func (c *Config) ActiveProfile(override string) string {
+	if override != "" {
+		return override
+	}
+	if env := strings.TrimSpace(os.Getenv(ProfileEnv)); env != "" {
+		return env
+	}
+	return c.Profile
+}
+
+// LookupProfile returns the named profile.
+func (c *Config) LookupProfile(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return Profile{}, fmt.Errorf("unknown profile %q: no profiles are defined", name)
+		}
+		return Profile{}, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	return p, nil
+}
+
+// ProfileNames returns every profile name in alphabetical order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetProfile adds or replaces a profile. Call Save to persist it.
+func (c *Config) SetProfile(name string, p Profile) error {
+	if !profileName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	c.Profiles[name] = p
+	return nil
+}
+
+// RemoveProfile deletes a profile, clearing the saved default if it pointed
+// there, and reports whether it existed.
+func (c *Config) RemoveProfile(name string) bool {
+	if _, ok := c.Profiles[name]; !ok {
+		return false
+	}
+	delete(c.Profiles, name)
+	if c.Profile == name {
+		c.Profile = ""
+	}
+	return true
+}
+
+// UseProfile makes name the saved default profile. An empty name clears it.
+func (c *Config) UseProfile(name string) error {
+	if name != "" {
+		if _, err := c.LookupProfile(name); err != nil {
+			return err
+		}
+	}
+	c.Profile = name
+	return nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Entry = 1
+	c.Leagues = []int{100}
+	if err := c.SetProfile("alice", Profile{Entry: 11, Rivals: []int{22, 33}}); err != nil {
+		t.Fatalf("set profile: %v", err)
+	}
+	if err := c.SetProfile("bob smith", Profile{Entry: 12}); err == nil {
+		t.Fatal("expected names with spaces to be rejected")
+	}
+	if err := c.UseProfile("carol"); err == nil {
+		t.Fatal("expected unknown profile to be rejected")
+	}
+	if err := c.UseProfile("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ProfileEnv, "")
+	if got := c.ActiveProfile(""); got != "alice" {
+		t.Fatalf("expected saved default profile, got %q", got)
+	}
+	eff, sources, err := c.Effective(c.ActiveProfile(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eff.Entry != 11 || sources["entry"] != SourceProfile {
+		t.Fatalf("expected profile entry, got %d from %s", eff.Entry, sources["entry"])
+	}
+	if !reflect.DeepEqual(eff.Leagues, []int{100}) || sources["leagues"] != SourceFile {
+		t.Fatalf("expected unset profile leagues to fall through to file, got %v from %s", eff.Leagues, sources["leagues"])
+	}
+	if !reflect.DeepEqual(eff.Rivals, []int{22, 33}) {
+		t.Fatalf("unexpected rivals %v", eff.Rivals)
+	}
+
+	t.Setenv("FPL_ENTRY", "99")
+	if eff, _, _ := c.Effective("alice"); eff.Entry != 99 {
+		t.Fatalf("expected env to beat profile, got %d", eff.Entry)
+	}
+
+	t.Setenv(ProfileEnv, "bob")
+	if got := c.ActiveProfile("alice"); got != "alice" {
+		t.Fatalf("expected flag to beat env, got %q", got)
+	}
+	if _, _, err := c.Effective(c.ActiveProfile("")); err == nil {
+		t.Fatal("expected unknown profile from env to fail")
+	}
+
+	if !c.RemoveProfile("alice") || c.Profile != "" {
+		t.Fatalf("expected removing the default profile to clear it, got %q", c.Profile)
+	}
+}
@@ -0,0 +1,133 @@
+package fpl
+
+import "sort"
+
+// Chips as named by active_chip and the entry history.
+const (
+	ChipBenchBoost    = "bboost"
+	ChipTripleCaptain = "3xc"
+	ChipFreeHit       = "freehit"
+	ChipWildcard      = "wildcard"
+)
+
+// benchStart is the first bench slot in Pick.Position.
+const benchStart = 12
+
+// minStarters is the fewest starters per element type a formation allows;
+// exactly one goalkeeper always starts.
+var minStarters = [5]int{0, 1, 3, 2, 1}
+
+// PlayStatus is whether a player has played in a gameweek.
+type PlayStatus int
+
+const (
+	// Pending players have not played yet but still might.
+	Pending PlayStatus = iota
+	// Played players have had minutes.
+	Played
+	// DidNotPlay players have no minutes and no matches left to play.
+	DidNotPlay
+)
+
+// SquadPlayer is a pick along with what the substitution rules need to know.
+type SquadPlayer struct {
+	Pick
+	ElementType int
+	Status      PlayStatus
+}
+
+// Lineup is a squad after automatic substitutions.
+type Lineup struct {
+	// Multipliers maps every element in the squad to the multiplier its
+	// points count with: 0 on the bench, 1 in the XI and 2 or 3 for the
+	// captain.
+	Multipliers map[int]int
+	Subs        []AutomaticSub
+	// Captain is the player wearing the armband: the vice-captain when the
+	// captain did not play, or 0 when neither did.
+	Captain int
+}
+
+// ApplyAutoSubs applies FPL's automatic substitutions to a squad. Each
+// starter who did not play is replaced by the first bench player, in bench
+// order, who has played or still might and who keeps the formation legal:
+// one goalkeeper, at least three defenders, two midfielders and one forward.
+// Goalkeepers are only swapped for goalkeepers. If the captain did not play,
+// the vice-captain takes their multiplier. Bench Boost scores the whole
+// squad, so nobody is substituted.
+func ApplyAutoSubs(squad []SquadPlayer, chip string) Lineup {
+	lineup := Lineup{Multipliers: make(map[int]int, len(squad))}
+	var starters, bench []SquadPlayer
+	var formation [5]int
+	for _, p := range squad {
+		if p.Position < benchStart || chip == ChipBenchBoost {
+			starters = append(starters, p)
+			lineup.Multipliers[p.Element] = 1
+			if p.ElementType > 0 && p.ElementType < len(formation) {
+				formation[p.ElementType]++
+			}
+		} else {
+			bench = append(bench, p)
+			lineup.Multipliers[p.Element] = 0
+		}
+	}
+	sortByPosition(starters)
+	sortByPosition(bench)
+
+	used := make([]bool, len(bench))
+	for _, out := range starters {
+		if out.Status != DidNotPlay {
+			continue
+		}
+		for i, in := range bench {
+			if used[i] || in.Status == DidNotPlay || !canSwap(formation, out.ElementType, in.ElementType) {
+				continue
+			}
+			used[i] = true
+			formation[out.ElementType]--
+			formation[in.ElementType]++
+			lineup.Multipliers[out.Element] = 0
+			lineup.Multipliers[in.Element] = 1
+			lineup.Subs = append(lineup.Subs, AutomaticSub{ElementIn: in.Element, ElementOut: out.Element})
+			break
+		}
+	}
+
+	armband := 2
+	if chip == ChipTripleCaptain {
+		armband = 3
+	}
+	var captain, vice *SquadPlayer
+	for i := range squad {
+		switch {
+		case squad[i].IsCaptain:
+			captain = &squad[i]
+		case squad[i].IsViceCaptain:
+			vice = &squad[i]
+		}
+	}
+	for _, p := range []*SquadPlayer{captain, vice} {
+		if p != nil && p.Status != DidNotPlay && lineup.Multipliers[p.Element] > 0 {
+			lineup.Captain = p.Element
+			lineup.Multipliers[p.Element] = armband
+			break
+		}
+	}
+	return lineup
+}
+
+// canSwap reports whether a starter of type out can make way for a bench
+// player of type in without breaking the formation.
+func canSwap(formation [5]int, out, in int) bool {
+	if (out == 1) != (in == 1) {
+		return false
+	}
+	if out == in {
+		return true
+	}
+	return formation[out]-1 >= minStarters[out]
+}
+
+func sortByPosition(players []SquadPlayer) {
+	sort.SliceStable(players, func(i, j int) bool { return players[i].Position < players[j].Position })
+}
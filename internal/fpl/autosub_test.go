@@ -0,0 +1,85 @@
+package fpl
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testSquad is a 3-4-3 with a bench of GKP, MID, DEF, FWD. Slot 1 is the
+// goalkeeper, 2-4 defenders, 5-8 midfielders and 9-11 forwards; element IDs
+// match slots.
+func testSquad(dnp ...int) []SquadPlayer {
+	types := []int{0, 1, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 1, 3, 2, 4}
+	squad := make([]SquadPlayer, 0, 15)
+	for slot := 1; slot <= 15; slot++ {
+		squad = append(squad, SquadPlayer{
+			Pick:        Pick{Element: slot, Position: slot, IsCaptain: slot == 9, IsViceCaptain: slot == 5},
+			ElementType: types[slot],
+			Status:      Played,
+		})
+	}
+	for _, slot := range dnp {
+		squad[slot-1].Status = DidNotPlay
+	}
+	return squad
+}
+
+func TestApplyAutoSubs(t *testing.T) {
+	tests := []struct {
+		name    string
+		dnp     []int
+		chip    string
+		subs    []AutomaticSub
+		captain int
+	}{
+		{name: "everyone played", captain: 9},
+		{name: "goalkeeper swap", dnp: []int{1}, subs: []AutomaticSub{{ElementIn: 12, ElementOut: 1}}, captain: 9},
+		{name: "first outfield bench player", dnp: []int{6}, subs: []AutomaticSub{{ElementIn: 13, ElementOut: 6}}, captain: 9},
+		// Only three defenders start, so a defender must replace one.
+		{name: "formation minimum", dnp: []int{2}, subs: []AutomaticSub{{ElementIn: 14, ElementOut: 2}}, captain: 9},
+		{name: "bench player who did not play is skipped", dnp: []int{7, 13}, subs: []AutomaticSub{{ElementIn: 14, ElementOut: 7}}, captain: 9},
+		{name: "vice promoted", dnp: []int{9}, subs: []AutomaticSub{{ElementIn: 13, ElementOut: 9}}, captain: 5},
+		{name: "neither captain played", dnp: []int{5, 9, 13, 14, 15}},
+		{name: "bench boost", dnp: []int{1}, chip: ChipBenchBoost, captain: 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lineup := ApplyAutoSubs(testSquad(tt.dnp...), tt.chip)
+			if !reflect.DeepEqual(lineup.Subs, tt.subs) {
+				t.Fatalf("expected subs %v, got %v", tt.subs, lineup.Subs)
+			}
+			if lineup.Captain != tt.captain {
+				t.Fatalf("expected captain %d, got %d", tt.captain, lineup.Captain)
+			}
+			if tt.captain != 0 && lineup.Multipliers[tt.captain] != 2 {
+				t.Fatalf("expected the captain doubled, got %d", lineup.Multipliers[tt.captain])
+			}
+			starting := 0
+			for _, m := range lineup.Multipliers {
+				if m > 0 {
+					starting++
+				}
+			}
+			if want := 11; tt.chip == ChipBenchBoost {
+				if starting != 15 {
+					t.Fatalf("expected all 15 in the XI with bench boost, got %d", starting)
+				}
+			} else if starting != want {
+				t.Fatalf("expected %d in the XI, got %d", want, starting)
+			}
+		})
+	}
+}
+
+func TestApplyAutoSubsPending(t *testing.T) {
+	squad := testSquad(6)
+	squad[12].Status = Pending
+	squad[8].Status = Pending
+	lineup := ApplyAutoSubs(squad, ChipTripleCaptain)
+	if len(lineup.Subs) != 1 || lineup.Subs[0].ElementIn != 13 {
+		t.Fatalf("expected a bench player yet to play to come on, got %v", lineup.Subs)
+	}
+	if lineup.Captain != 9 || lineup.Multipliers[9] != 3 {
+		t.Fatalf("expected the captain yet to play tripled, got %d x%d", lineup.Captain, lineup.Multipliers[9])
+	}
+}
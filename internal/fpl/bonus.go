@@ -0,0 +1,62 @@
+package fpl
+
+import "sort"
+
+// bonusAwards are the bonus points for the best, second and third BPS in a
+// fixture.
+var bonusAwards = [...]int{3, 2, 1}
+
+// Stat returns every player's value for the named fixture statistic, or
+// nil when the fixture does not record it.
+func (f *Fixture) Stat(identifier string) []StatValue {
+	for _, s := range f.Stats {
+		if s.Identifier == identifier {
+			return append(append([]StatValue(nil), s.Home...), s.Away...)
+		}
+	}
+	return nil
+}
+
+// BonusConfirmed reports whether the fixture's bonus points have been added
+// to player totals, which happens once its result is confirmed.
+func (f *Fixture) BonusConfirmed() bool {
+	return f.Finished
+}
+
+// ProvisionalBonus awards bonus points from the fixture's BPS as things
+// stand: 3, 2 and 1 to the top three. Tied players share the higher award
+// and the next one is skipped, so a tie for first gives 3, 3, 1 and a tie
+// for second 3, 2, 2. Players outside the top three get nothing.
+func ProvisionalBonus(f *Fixture) map[int]int {
+	bps := f.Stat("bps")
+	sort.SliceStable(bps, func(i, j int) bool { return bps[i].Value > bps[j].Value })
+	bonus := make(map[int]int)
+	rank := 0
+	for i, v := range bps {
+		if i == 0 || v.Value < bps[i-1].Value {
+			rank = i
+		}
+		if rank >= len(bonusAwards) {
+			break
+		}
+		bonus[v.Element] = bonusAwards[rank]
+	}
+	return bonus
+}
+
+// ProvisionalBonuses sums provisional bonus over gameweek gw's fixtures that
+// have started but whose bonus is not yet confirmed, so it can be added to
+// live points without counting any twice.
+func ProvisionalBonuses(fixtures []Fixture, gw int) map[int]int {
+	total := make(map[int]int)
+	for i := range fixtures {
+		f := &fixtures[i]
+		if f.Event == nil || *f.Event != gw || !f.Started || f.BonusConfirmed() {
+			continue
+		}
+		for id, b := range ProvisionalBonus(f) {
+			total[id] += b
+		}
+	}
+	return total
+}
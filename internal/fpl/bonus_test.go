@@ -0,0 +1,56 @@
+package fpl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func bpsFixture(gw int, started, finished bool, values ...int) Fixture {
+	stat := FixtureStat{Identifier: "bps"}
+	for i, v := range values {
+		sv := StatValue{Element: i + 1, Value: v}
+		if i%2 == 0 {
+			stat.Home = append(stat.Home, sv)
+		} else {
+			stat.Away = append(stat.Away, sv)
+		}
+	}
+	return Fixture{Event: &gw, Started: started, Finished: finished, Stats: []FixtureStat{{Identifier: "goals_scored"}, stat}}
+}
+
+func TestProvisionalBonus(t *testing.T) {
+	tests := []struct {
+		name string
+		bps  []int
+		want map[int]int
+	}{
+		{"clear order", []int{30, 40, 20, 10}, map[int]int{2: 3, 1: 2, 3: 1}},
+		{"tie for first", []int{40, 40, 30, 20}, map[int]int{1: 3, 2: 3, 3: 1}},
+		{"three-way tie for first", []int{40, 40, 40, 30}, map[int]int{1: 3, 2: 3, 3: 3}},
+		{"tie for second", []int{50, 40, 40, 30}, map[int]int{1: 3, 2: 2, 3: 2}},
+		{"tie for third", []int{50, 40, 30, 30, 20}, map[int]int{1: 3, 2: 2, 3: 1, 4: 1}},
+		{"no bps yet", nil, map[int]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := bpsFixture(1, true, false, tt.bps...)
+			if got := ProvisionalBonus(&f); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProvisionalBonuses(t *testing.T) {
+	fixtures := []Fixture{
+		bpsFixture(5, true, false, 30, 20, 10),
+		// Confirmed bonus is already in live totals.
+		bpsFixture(5, true, true, 30, 20, 10),
+		bpsFixture(5, false, false),
+		bpsFixture(6, true, false, 10, 20, 30),
+	}
+	want := map[int]int{1: 3, 2: 2, 3: 1}
+	if got := ProvisionalBonuses(fixtures, 5); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
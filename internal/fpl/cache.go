@@ -0,0 +1,64 @@
+package fpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const bootstrapCacheName = "bootstrap-static"
+
+// DefaultCacheDir returns the on-disk cache location under the user cache
+// directory, e.g. ~/.cache/fpl.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "fpl"), nil
+}
+
+// readCacheFile decodes path into target when the file is younger than
+// maxAge. A negative maxAge accepts a file of any age.
+func readCacheFile(path string, maxAge time.Duration, target any) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if maxAge >= 0 && time.Since(info.ModTime()) > maxAge {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, target) == nil
+}
+
+// writeCacheFile stores v as JSON at path, replacing any previous file
+// atomically so concurrent readers never see a partial write.
+func writeCacheFile(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
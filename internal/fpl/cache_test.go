@@ -0,0 +1,99 @@
+package fpl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestBootstrapDiskCache(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"elements":[{"id":1,"web_name":"Salah"}],"teams":[],"element_types":[],"events":[{"id":1,"finished":true}]}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	newTestClient := func(ttl time.Duration) *Client {
+		c := NewClient(srv.Client(), ttl)
+		c.SetBaseURL(srv.URL + "/")
+		c.SetCacheDir(dir)
+		return c
+	}
+	ctx := context.Background()
+
+	if _, err := newTestClient(time.Minute).Bootstrap(ctx); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	b, err := newTestClient(time.Minute).Bootstrap(ctx)
+	if err != nil {
+		t.Fatalf("cached fetch: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected a fresh client to reuse the disk cache, got %d requests", requests)
+	}
+	if len(b.Elements) != 1 || b.Elements[0].WebName != "Salah" || len(b.Events) != 1 {
+		t.Fatalf("unexpected cached payload: %+v", b)
+	}
+
+	stale := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(newTestClient(0).cachePath(bootstrapCacheName), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newTestClient(time.Minute).CachedBootstrap(ctx); err != nil || requests != 1 {
+		t.Fatalf("expected CachedBootstrap to accept a stale file, got %d requests (%v)", requests, err)
+	}
+	if _, err := newTestClient(time.Minute).Bootstrap(ctx); err != nil || requests != 2 {
+		t.Fatalf("expected Bootstrap to refetch a stale file, got %d requests (%v)", requests, err)
+	}
+	if _, err := newTestClient(0).Bootstrap(ctx); err != nil || requests != 3 {
+		t.Fatalf("expected a zero TTL to bypass the cache, got %d requests (%v)", requests, err)
+	}
+}
+
+func TestFinishedEventLiveDiskCache(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"elements":[{"id":7,"stats":{"minutes":90,"total_points":6},"explain":[{"fixture":1,"stats":[{"identifier":"goals_scored","points":4,"value":1}]}]}]}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	newTestClient := func(ttl time.Duration) *Client {
+		c := NewClient(srv.Client(), ttl)
+		c.SetBaseURL(srv.URL)
+		c.SetCacheDir(dir)
+		return c
+	}
+	ctx := context.Background()
+
+	if _, err := newTestClient(time.Minute).FinishedEventLive(ctx, 3); err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	stale := time.Now().Add(-24 * time.Hour)
+	if err := os.Chtimes(newTestClient(0).cachePath("event-3-live"), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	live, err := newTestClient(time.Minute).FinishedEventLive(ctx, 3)
+	if err != nil || requests != 1 {
+		t.Fatalf("expected a finished gameweek served from disk at any age, got %d requests (%v)", requests, err)
+	}
+	if len(live.Elements) != 1 || live.Elements[0].Stats.TotalPoints != 6 || len(live.Elements[0].Explain) != 1 {
+		t.Fatalf("unexpected cached payload: %+v", live)
+	}
+
+	if _, err := newTestClient(time.Minute).FinishedEventLive(ctx, 4); err != nil || requests != 2 {
+		t.Fatalf("expected each gameweek cached separately, got %d requests (%v)", requests, err)
+	}
+	if _, err := newTestClient(time.Minute).EventLive(ctx, 3); err != nil || requests != 3 {
+		t.Fatalf("expected EventLive to bypass the cache, got %d requests (%v)", requests, err)
+	}
+	if _, err := newTestClient(0).FinishedEventLive(ctx, 3); err != nil || requests != 4 {
+		t.Fatalf("expected a zero TTL to bypass the cache, got %d requests (%v)", requests, err)
+	}
+}
@@ -4,18 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
-const defaultBaseURL = "https://fantasy.premierleague.com/api"
+// DefaultBaseURL is the public FPL API root.
+const DefaultBaseURL = "https://fantasy.premierleague.com/api"
 
 // Client wraps HTTP access to the FPL API.
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
 	cacheTTL   time.Duration
+	cacheDir   string
 
 	bootstrapCache struct {
 		data   *BootstrapStatic
@@ -30,16 +35,36 @@ func NewClient(httpClient *http.Client, cacheTTL time.Duration) *Client {
 	}
 	return &Client{
 		httpClient: httpClient,
-		baseURL:    defaultBaseURL,
+		baseURL:    DefaultBaseURL,
 		cacheTTL:   cacheTTL,
 	}
 }
 
+// SetBaseURL points the client at another API root, such as a mirror or a
+// local fixture server. Trailing slashes are ignored.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetCacheDir persists bootstrap-static responses under dir so that later
+// invocations within the cache TTL skip the network, along with the live
+// data of finished gameweeks. An empty dir disables the on-disk cache.
+func (c *Client) SetCacheDir(dir string) {
+	c.cacheDir = dir
+}
+
 // Bootstrap fetches /bootstrap-static/, optionally serving from cache.
 func (c *Client) Bootstrap(ctx context.Context) (*BootstrapStatic, error) {
 	if c.cacheTTL > 0 && c.bootstrapCache.data != nil && time.Now().Before(c.bootstrapCache.expiry) {
 		return c.bootstrapCache.data, nil
 	}
+	if c.cacheTTL > 0 && c.cacheDir != "" {
+		var cached BootstrapStatic
+		if readCacheFile(c.cachePath(bootstrapCacheName), c.cacheTTL, &cached) {
+			c.rememberBootstrap(&cached)
+			return &cached, nil
+		}
+	}
 
 	var payload BootstrapStatic
 	if err := c.get(ctx, "/bootstrap-static/", &payload); err != nil {
@@ -47,13 +72,46 @@ func (c *Client) Bootstrap(ctx context.Context) (*BootstrapStatic, error) {
 	}
 
 	if c.cacheTTL > 0 {
-		c.bootstrapCache.data = &payload
-		c.bootstrapCache.expiry = time.Now().Add(c.cacheTTL)
+		c.rememberBootstrap(&payload)
+	}
+	if c.cacheDir != "" {
+		// A failed cache write only costs a refetch next time.
+		_ = writeCacheFile(c.cachePath(bootstrapCacheName), &payload)
 	}
 
 	return &payload, nil
 }
 
+// CachedBootstrap returns the on-disk bootstrap payload regardless of its
+// age, falling back to Bootstrap when nothing is cached. It suits callers
+// such as shell completion that value speed over freshness.
+func (c *Client) CachedBootstrap(ctx context.Context) (*BootstrapStatic, error) {
+	if c.cacheDir != "" {
+		var cached BootstrapStatic
+		if readCacheFile(c.cachePath(bootstrapCacheName), -1, &cached) {
+			return &cached, nil
+		}
+	}
+	return c.Bootstrap(ctx)
+}
+
+func (c *Client) rememberBootstrap(b *BootstrapStatic) {
+	c.bootstrapCache.data = b
+	c.bootstrapCache.expiry = time.Now().Add(c.cacheTTL)
+}
+
+// cachePath names the cache file for a payload, keeping payloads from
+// different API roots apart so a mirror never serves stale data for the
+// public API or vice versa.
+func (c *Client) cachePath(name string) string {
+	if c.baseURL == DefaultBaseURL {
+		return filepath.Join(c.cacheDir, name+".json")
+	}
+	h := fnv.New32a()
+	h.Write([]byte(c.baseURL))
+	return filepath.Join(c.cacheDir, fmt.Sprintf("%s-%08x.json", name, h.Sum32()))
+}
+
 // PlayerSummary fetches /element-summary/{id}/ for a player.
 func (c *Client) PlayerSummary(ctx context.Context, id int) (*PlayerSummary, error) {
 	var payload PlayerSummary
@@ -63,6 +121,92 @@ func (c *Client) PlayerSummary(ctx context.Context, id int) (*PlayerSummary, err
 	return &payload, nil
 }
 
+// Fixtures fetches every fixture of the season from /fixtures/.
+func (c *Client) Fixtures(ctx context.Context) ([]Fixture, error) {
+	var payload []Fixture
+	if err := c.get(ctx, "/fixtures/", &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Entry fetches /entry/{id}/ for a manager.
+func (c *Client) Entry(ctx context.Context, id int) (*Entry, error) {
+	var payload Entry
+	if err := c.get(ctx, fmt.Sprintf("/entry/%d/", id), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// EntryHistory fetches /entry/{id}/history/ for a manager.
+func (c *Client) EntryHistory(ctx context.Context, id int) (*EntryHistory, error) {
+	var payload EntryHistory
+	if err := c.get(ctx, fmt.Sprintf("/entry/%d/history/", id), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// EntryPicks fetches a manager's squad for gameweek gw.
+func (c *Client) EntryPicks(ctx context.Context, id, gw int) (*EntryPicks, error) {
+	var payload EntryPicks
+	if err := c.get(ctx, fmt.Sprintf("/entry/%d/event/%d/picks/", id, gw), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// EntryTransfers fetches every transfer a manager has made this season.
+func (c *Client) EntryTransfers(ctx context.Context, id int) ([]Transfer, error) {
+	var payload []Transfer
+	if err := c.get(ctx, fmt.Sprintf("/entry/%d/transfers/", id), &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// LeagueStandings fetches one page of a classic league's standings,
+// counting pages from 1.
+func (c *Client) LeagueStandings(ctx context.Context, id, page int) (*LeagueStandings, error) {
+	var payload LeagueStandings
+	if err := c.get(ctx, fmt.Sprintf("/leagues-classic/%d/standings/?page_standings=%d", id, page), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// EventLive fetches every player's live stats for gameweek gw.
+func (c *Client) EventLive(ctx context.Context, gw int) (*Live, error) {
+	var payload Live
+	if err := c.get(ctx, fmt.Sprintf("/event/%d/live/", gw), &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// FinishedEventLive is EventLive for a gameweek whose data FPL has checked.
+// Such payloads no longer change, so they are kept on disk regardless of
+// age; a zero cache TTL still refetches them.
+func (c *Client) FinishedEventLive(ctx context.Context, gw int) (*Live, error) {
+	path := c.cachePath(fmt.Sprintf("event-%d-live", gw))
+	if c.cacheTTL > 0 && c.cacheDir != "" {
+		var cached Live
+		if readCacheFile(path, -1, &cached) {
+			return &cached, nil
+		}
+	}
+	live, err := c.EventLive(ctx, gw)
+	if err != nil {
+		return nil, err
+	}
+	if c.cacheDir != "" {
+		// A failed cache write only costs a refetch next time.
+		_ = writeCacheFile(path, live)
+	}
+	return live, nil
+}
+
 func (c *Client) get(ctx context.Context, path string, target any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
 	if err != nil {
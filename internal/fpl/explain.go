@@ -0,0 +1,53 @@
+package fpl
+
+// Points categories that explain identifiers are grouped into.
+const (
+	CategoryAppearance  = "appearance"
+	CategoryGoals       = "goals"
+	CategoryAssists     = "assists"
+	CategoryCleanSheets = "clean_sheets"
+	CategoryConceded    = "goals_conceded"
+	CategorySaves       = "saves"
+	CategoryBonus       = "bonus"
+	CategoryCards       = "cards"
+	CategoryDefensive   = "defensive_contribution"
+	CategoryOther       = "other"
+)
+
+// explainCategories maps explain identifiers to categories; anything else,
+// such as own goals and missed penalties, is CategoryOther.
+var explainCategories = map[string]string{
+	"minutes":                CategoryAppearance,
+	"goals_scored":           CategoryGoals,
+	"assists":                CategoryAssists,
+	"clean_sheets":           CategoryCleanSheets,
+	"goals_conceded":         CategoryConceded,
+	"saves":                  CategorySaves,
+	"penalties_saved":        CategorySaves,
+	"bonus":                  CategoryBonus,
+	"yellow_cards":           CategoryCards,
+	"red_cards":              CategoryCards,
+	"defensive_contribution": CategoryDefensive,
+}
+
+// ExplainCategory returns the points category of an explain identifier.
+func ExplainCategory(identifier string) string {
+	if c, ok := explainCategories[identifier]; ok {
+		return c
+	}
+	return CategoryOther
+}
+
+// PointsByCategory sums the player's points by category over every fixture
+// in the gameweek. Categories that scored nothing are omitted.
+func (e *LiveElement) PointsByCategory() map[string]int {
+	points := make(map[string]int)
+	for _, f := range e.Explain {
+		for _, s := range f.Stats {
+			if s.Points != 0 {
+				points[ExplainCategory(s.Identifier)] += s.Points
+			}
+		}
+	}
+	return points
+}
@@ -0,0 +1,39 @@
+package fpl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPointsByCategory(t *testing.T) {
+	// A double gameweek: a goal and bonus in the first fixture, a booking and
+	// a missed penalty in the second.
+	payload := `{"id": 7, "explain": [
+		{"fixture": 1, "stats": [
+			{"identifier": "minutes", "points": 2, "value": 90},
+			{"identifier": "goals_scored", "points": 5, "value": 1},
+			{"identifier": "bonus", "points": 3, "value": 3},
+			{"identifier": "bps", "points": 0, "value": 41}
+		]},
+		{"fixture": 2, "stats": [
+			{"identifier": "minutes", "points": 1, "value": 30},
+			{"identifier": "yellow_cards", "points": -1, "value": 1},
+			{"identifier": "penalties_missed", "points": -2, "value": 1}
+		]}
+	]}`
+	var el LiveElement
+	if err := json.Unmarshal([]byte(payload), &el); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string]int{
+		CategoryAppearance: 3,
+		CategoryGoals:      5,
+		CategoryBonus:      3,
+		CategoryCards:      -1,
+		CategoryOther:      -2,
+	}
+	if got := el.PointsByCategory(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("PointsByCategory() = %v, want %v", got, want)
+	}
+}
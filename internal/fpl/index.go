@@ -0,0 +1,144 @@
+package fpl
+
+import (
+	"errors"
+	"sort"
+)
+
+// PlayerIndex is built once from a BootstrapStatic payload and answers player,
+// team and position lookups without rescanning the underlying slices. Name
+// searches reuse the normalized name variants computed at construction.
+type PlayerIndex struct {
+	bootstrap *BootstrapStatic
+	byID      map[int]*Element
+	byCode    map[int]*Element
+	teams     map[int]*Team
+	positions map[int]*ElementType
+	byTeam    map[int][]*Element
+	byType    map[int][]*Element
+	teamNames *teamLookup
+	posNames  *positionLookup
+	// names holds the name targets of every player, of each team, of each
+	// position and of each position within a team, so that a filtered
+	// search only ranks the players it can return.
+	names map[nameFilter]*nameTargets
+}
+
+// nameFilter is the team and position a name search is restricted to, zero
+// for either.
+type nameFilter struct {
+	team, elementType int
+}
+
+// NewPlayerIndex indexes the players, teams and positions in b. The index
+// keeps pointers into b, which must not be modified afterwards.
+func NewPlayerIndex(b *BootstrapStatic) *PlayerIndex {
+	ix := &PlayerIndex{
+		bootstrap: b,
+		byID:      make(map[int]*Element, len(b.Elements)),
+		byCode:    make(map[int]*Element, len(b.Elements)),
+		teams:     make(map[int]*Team, len(b.Teams)),
+		positions: make(map[int]*ElementType, len(b.ElementTypes)),
+		byTeam:    make(map[int][]*Element, len(b.Teams)),
+		byType:    make(map[int][]*Element, len(b.ElementTypes)),
+		teamNames: newTeamLookup(b.Teams),
+		posNames:  newPositionLookup(b.ElementTypes),
+		names:     map[nameFilter]*nameTargets{{}: {}},
+	}
+
+	for i := range b.Elements {
+		el := &b.Elements[i]
+		variants := nameVariants(el)
+		normalized := normalizeAll(variants)
+		for _, f := range []nameFilter{{}, {team: el.Team}, {elementType: el.ElementType}, {el.Team, el.ElementType}} {
+			t := ix.names[f]
+			if t == nil {
+				t = &nameTargets{}
+				ix.names[f] = t
+			}
+			t.add(el, variants, normalized)
+		}
+		ix.byID[el.ID] = el
+		ix.byCode[el.Code] = el
+		ix.byTeam[el.Team] = append(ix.byTeam[el.Team], el)
+		ix.byType[el.ElementType] = append(ix.byType[el.ElementType], el)
+	}
+	for i := range b.Teams {
+		ix.teams[b.Teams[i].ID] = &b.Teams[i]
+	}
+	for i := range b.ElementTypes {
+		ix.positions[b.ElementTypes[i].ID] = &b.ElementTypes[i]
+	}
+	return ix
+}
+
+// Bootstrap returns the payload the index was built from.
+func (ix *PlayerIndex) Bootstrap() *BootstrapStatic {
+	return ix.bootstrap
+}
+
+// Player returns the player with the given element ID, or nil.
+func (ix *PlayerIndex) Player(id int) *Element {
+	return ix.byID[id]
+}
+
+// PlayerByCode returns the player with the given season-stable code, or nil.
+func (ix *PlayerIndex) PlayerByCode(code int) *Element {
+	return ix.byCode[code]
+}
+
+// Team returns the team with the given ID, or nil.
+func (ix *PlayerIndex) Team(id int) *Team {
+	return ix.teams[id]
+}
+
+// Position returns the element type with the given ID, or nil.
+func (ix *PlayerIndex) Position(id int) *ElementType {
+	return ix.positions[id]
+}
+
+// TeamPlayers returns the players registered to a team, ordered by ID.
+func (ix *PlayerIndex) TeamPlayers(teamID int) []*Element {
+	return ix.byTeam[teamID]
+}
+
+// PositionPlayers returns the players in a position, ordered by ID.
+func (ix *PlayerIndex) PositionPlayers(elementTypeID int) []*Element {
+	return ix.byType[elementTypeID]
+}
+
+// TeamShortNames returns every team's short name in alphabetical order.
+func (ix *PlayerIndex) TeamShortNames() []string {
+	names := make([]string, 0, len(ix.bootstrap.Teams))
+	for _, t := range ix.bootstrap.Teams {
+		names = append(names, t.ShortName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseQuery is ParsePlayerQuery against the indexed teams and positions.
+func (ix *PlayerIndex) ParseQuery(raw string) (PlayerQuery, error) {
+	return parsePlayerQuery(raw, ix.teamNames, ix.posNames)
+}
+
+// ResolveTeam is ResolveTeam against the indexed teams.
+func (ix *PlayerIndex) ResolveTeam(value string) (*Team, error) {
+	return ix.teamNames.resolve(value)
+}
+
+// ResolvePosition is ResolvePosition against the indexed positions.
+func (ix *PlayerIndex) ResolvePosition(value string) (*ElementType, error) {
+	return ix.posNames.resolve(value)
+}
+
+// Find resolves q's name among the players matching its filters only,
+// checking aliases (which may be nil) before fuzzy matching and reusing the
+// prebuilt name variants.
+func (ix *PlayerIndex) Find(q PlayerQuery, aliases AliasSource) (*Element, []MatchSuggestion, error) {
+	targets := ix.names[nameFilter{q.TeamID, q.ElementTypeID}]
+	if targets == nil {
+		return nil, nil, errors.New("no players match the team/position filters")
+	}
+	return targets.find(q.Name, aliases)
+}
@@ -0,0 +1,129 @@
+package fpl
+
+import "testing"
+
+func TestPlayerIndex(t *testing.T) {
+	bootstrap := &BootstrapStatic{
+		Elements: []Element{
+			{ID: 1, Code: 100, WebName: "Johnson", FirstName: "Ben", SecondName: "Johnson", Team: 4, ElementType: 2},
+			{ID: 2, Code: 200, WebName: "Johnson", FirstName: "Brennan", SecondName: "Johnson", Team: 3, ElementType: 3},
+			{ID: 3, Code: 300, WebName: "Salah", FirstName: "Mohamed", SecondName: "Salah", Team: 1, ElementType: 3},
+		},
+		Teams:        testTeams,
+		ElementTypes: testTypes,
+	}
+	ix := NewPlayerIndex(bootstrap)
+
+	if p := ix.Player(3); p == nil || p.WebName != "Salah" {
+		t.Fatalf("expected Salah by ID, got %+v", p)
+	}
+	if p := ix.PlayerByCode(200); p == nil || p.ID != 2 {
+		t.Fatalf("expected player 2 by code, got %+v", p)
+	}
+	if ix.Player(99) != nil || ix.Team(99) != nil || ix.Position(99) != nil {
+		t.Fatal("expected nil for unknown IDs")
+	}
+	if team := ix.Team(3); team == nil || team.ShortName != "TOT" {
+		t.Fatalf("unexpected team %+v", team)
+	}
+	if mids := ix.PositionPlayers(3); len(mids) != 2 {
+		t.Fatalf("expected two midfielders, got %d", len(mids))
+	}
+
+	q, err := ix.ParseQuery("Johnson TOT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	player, suggestions, err := ix.Find(q, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if player.ID != 2 || len(suggestions) != 1 {
+		t.Fatalf("expected filtered match on the Spurs Johnson, got %+v %+v", player, suggestions)
+	}
+
+	if _, _, err := ix.Find(PlayerQuery{Name: "Johnson", TeamID: 2}, nil); err == nil {
+		t.Fatal("expected error when no player matches the filters")
+	}
+	if _, _, err := ix.Find(PlayerQuery{Name: "Johnson", TeamID: 4, ElementTypeID: 3}, nil); err == nil {
+		t.Fatal("expected error when no player matches both filters")
+	}
+	// Filtered searches only rank the players they can return.
+	if _, suggestions, err := ix.Find(PlayerQuery{Name: "john", ElementTypeID: 2}, nil); err != nil || len(suggestions) != 1 || suggestions[0].Element.ID != 1 {
+		t.Fatalf("expected only the defender suggested, got %+v, %v", suggestions, err)
+	}
+}
+
+func TestPlayerIndexLookups(t *testing.T) {
+	ix := NewPlayerIndex(&BootstrapStatic{
+		Elements: []Element{
+			{ID: 1, WebName: "Gabriel", FirstName: "Gabriel", SecondName: "dos Santos Magalhães", Team: 1, ElementType: 2},
+			{ID: 2, WebName: "Gabriela", FirstName: "Ana", SecondName: "Gabriela", Team: 2, ElementType: 3},
+		},
+		Teams:        append(testTeams, Team{ID: 5, Name: "Brentford", ShortName: "BRE"}),
+		ElementTypes: testTypes,
+	})
+
+	for value, want := range map[string]int{"tot": 3, "Spurs": 3, "liver": 1, "Brighton": 4} {
+		if team, err := ix.ResolveTeam(value); err != nil || team.ID != want {
+			t.Errorf("ResolveTeam(%q) = %+v, %v; want team %d", value, team, err, want)
+		}
+	}
+	if _, err := ix.ResolveTeam("Br"); err == nil {
+		t.Error("expected a prefix shared by two teams to be rejected")
+	}
+	for value, want := range map[string]int{"GK": 1, "defenders": 2, "Midfielder": 3, "fw": 4} {
+		if pos, err := ix.ResolvePosition(value); err != nil || pos.ID != want {
+			t.Errorf("ResolvePosition(%q) = %+v, %v; want position %d", value, pos, err, want)
+		}
+	}
+	if _, err := ix.ResolvePosition("striker"); err == nil {
+		t.Error("expected an unknown position to be rejected")
+	}
+
+	// An exact name skips fuzzy ranking, which would also suggest Gabriela.
+	player, suggestions, err := ix.Find(PlayerQuery{Name: "gabriel"}, nil)
+	if err != nil || player.ID != 1 || len(suggestions) != 1 || suggestions[0].Confidence != 1 {
+		t.Fatalf("expected only the exact Gabriel, got %+v %+v, %v", player, suggestions, err)
+	}
+	if _, suggestions, _ = ix.Find(PlayerQuery{Name: "gabri"}, nil); len(suggestions) != 2 {
+		t.Fatalf("expected both players for a partial name, got %+v", suggestions)
+	}
+}
+
+type stubAliases map[string]int
+
+func (s stubAliases) LookupCode(name string) (int, bool) {
+	code, ok := s[NormalizeName(name)]
+	return code, ok
+}
+
+func TestPlayerIndexFindChecksAliasesFirst(t *testing.T) {
+	ix := NewPlayerIndex(&BootstrapStatic{
+		Elements: []Element{
+			{ID: 10, Code: 61366, WebName: "De Bruyne", FirstName: "Kevin", SecondName: "De Bruyne", Team: 1, ElementType: 3},
+			{ID: 11, Code: 99999, WebName: "Kdbrook", FirstName: "Kay", SecondName: "Dbrook", Team: 2, ElementType: 3},
+		},
+		Teams:        testTeams,
+		ElementTypes: testTypes,
+	})
+	aliases := stubAliases{"kdb": 61366}
+
+	player, suggestions, err := ix.Find(PlayerQuery{Name: "KDB"}, aliases)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if player.ID != 10 || len(suggestions) != 1 || suggestions[0].Confidence != 1 {
+		t.Fatalf("expected alias to resolve exactly to De Bruyne, got %+v %+v", player, suggestions)
+	}
+
+	// An alias pointing at a player the filters leave out falls back to
+	// fuzzy matching.
+	player, _, err = ix.Find(PlayerQuery{Name: "KDB", TeamID: 2}, aliases)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if player.ID != 11 {
+		t.Fatalf("expected fuzzy fallback, got %+v", player)
+	}
+}
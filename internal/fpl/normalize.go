@@ -0,0 +1,45 @@
+package fpl
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations covers letters that do not decompose into an ASCII base
+// plus combining marks under NFD.
+var transliterations = map[rune]string{
+	'ø': "o", 'Ø': "o",
+	'æ': "ae", 'Æ': "ae",
+	'œ': "oe", 'Œ': "oe",
+	'ß': "ss",
+	'ł': "l", 'Ł': "l",
+	'đ': "d", 'Đ': "d",
+	'ð': "d", 'Ð': "d",
+	'þ': "th", 'Þ': "th",
+	'ı': "i",
+	'ħ': "h", 'Ħ': "h",
+	'ŋ': "n", 'Ŋ': "n",
+}
+
+// NormalizeName folds a player name for comparison: accents are removed,
+// special letters transliterated, case folded, and anything that is not a
+// letter or digit (spaces, apostrophes, hyphens, dots) dropped. "Ødegaard"
+// becomes "odegaard" and "Mac Allister" becomes "macallister".
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,251 @@
+package fpl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PlayerQuery is a player name search narrowed by optional team and position
+// filters. Zero IDs match any team or position.
+type PlayerQuery struct {
+	Name          string
+	TeamID        int
+	ElementTypeID int
+}
+
+var positionAliases = map[string]string{
+	"gk":  "GKP",
+	"gkp": "GKP",
+	"def": "DEF",
+	"mid": "MID",
+	"fw":  "FWD",
+	"fwd": "FWD",
+}
+
+// ParsePlayerQuery splits qualifiers out of a raw name query. Explicit
+// qualifiers use a prefix ("team:LIV", "pos:GKP"); bare tokens that exactly
+// match a team short name or position ("Johnson TOT", "Gordon NEW") are also
+// treated as qualifiers as long as some name text remains.
+func ParsePlayerQuery(raw string, teams []Team, types []ElementType) (PlayerQuery, error) {
+	return parsePlayerQuery(raw, newTeamLookup(teams), newPositionLookup(types))
+}
+
+func parsePlayerQuery(raw string, teams *teamLookup, types *positionLookup) (PlayerQuery, error) {
+	var q PlayerQuery
+	tokens := strings.Fields(raw)
+	nameTokens := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		key, value, found := strings.Cut(token, ":")
+		if !found {
+			nameTokens = append(nameTokens, token)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "team", "club":
+			team, err := teams.resolve(value)
+			if err != nil {
+				return PlayerQuery{}, err
+			}
+			if err := q.setTeam(team.ID); err != nil {
+				return PlayerQuery{}, err
+			}
+		case "pos", "position":
+			pos, err := types.resolve(value)
+			if err != nil {
+				return PlayerQuery{}, err
+			}
+			if err := q.setPosition(pos.ID); err != nil {
+				return PlayerQuery{}, err
+			}
+		default:
+			return PlayerQuery{}, fmt.Errorf("unknown qualifier %q: use team:<club> or pos:<position>", key+":")
+		}
+	}
+
+	remaining := make([]string, 0, len(nameTokens))
+	for i, token := range nameTokens {
+		// Always keep at least one name token, so a lone "MID" is still a name.
+		if len(remaining) == 0 && i == len(nameTokens)-1 {
+			remaining = append(remaining, token)
+			continue
+		}
+		if team := teams.short(token); team != nil && q.TeamID == 0 {
+			q.TeamID = team.ID
+			continue
+		}
+		if pos := types.short(token); pos != nil && q.ElementTypeID == 0 {
+			q.ElementTypeID = pos.ID
+			continue
+		}
+		remaining = append(remaining, token)
+	}
+
+	q.Name = strings.Join(remaining, " ")
+	return q, nil
+}
+
+// Merge combines q with filters supplied separately (e.g. via flags),
+// rejecting contradictory values.
+func (q PlayerQuery) Merge(teamID, elementTypeID int) (PlayerQuery, error) {
+	if err := q.setTeam(teamID); err != nil {
+		return PlayerQuery{}, err
+	}
+	if err := q.setPosition(elementTypeID); err != nil {
+		return PlayerQuery{}, err
+	}
+	return q, nil
+}
+
+func (q *PlayerQuery) setTeam(id int) error {
+	if id == 0 {
+		return nil
+	}
+	if q.TeamID != 0 && q.TeamID != id {
+		return errors.New("conflicting team qualifiers")
+	}
+	q.TeamID = id
+	return nil
+}
+
+func (q *PlayerQuery) setPosition(id int) error {
+	if id == 0 {
+		return nil
+	}
+	if q.ElementTypeID != 0 && q.ElementTypeID != id {
+		return errors.New("conflicting position qualifiers")
+	}
+	q.ElementTypeID = id
+	return nil
+}
+
+// Matches reports whether el satisfies the query's team and position filters.
+func (q PlayerQuery) Matches(el *Element) bool {
+	if q.TeamID != 0 && el.Team != q.TeamID {
+		return false
+	}
+	if q.ElementTypeID != 0 && el.ElementType != q.ElementTypeID {
+		return false
+	}
+	return true
+}
+
+// ResolveTeam finds a team by short name ("LIV"), full name ("Liverpool") or
+// an unambiguous prefix of the full name, ignoring case and accents.
+func ResolveTeam(value string, teams []Team) (*Team, error) {
+	return newTeamLookup(teams).resolve(value)
+}
+
+// ResolvePosition finds a position by short name ("GKP", "GK"), or singular
+// or plural name ("Goalkeeper", "Defenders").
+func ResolvePosition(value string, types []ElementType) (*ElementType, error) {
+	return newPositionLookup(types).resolve(value)
+}
+
+// teamLookup finds teams by their normalized names.
+type teamLookup struct {
+	// byName holds normalized short and full names, byShort upper-case short
+	// names as typed in bare qualifiers.
+	byName  map[string]*Team
+	byShort map[string]*Team
+	// full lists normalized full names in order, for prefix matching.
+	full []teamName
+}
+
+type teamName struct {
+	name string
+	team *Team
+}
+
+func newTeamLookup(teams []Team) *teamLookup {
+	l := &teamLookup{
+		byName:  make(map[string]*Team, 2*len(teams)),
+		byShort: make(map[string]*Team, len(teams)),
+		full:    make([]teamName, 0, len(teams)),
+	}
+	for i := range teams {
+		t := &teams[i]
+		name := NormalizeName(t.Name)
+		for _, key := range []string{NormalizeName(t.ShortName), name} {
+			if _, taken := l.byName[key]; !taken {
+				l.byName[key] = t
+			}
+		}
+		l.byShort[strings.ToUpper(t.ShortName)] = t
+		l.full = append(l.full, teamName{name, t})
+	}
+	return l
+}
+
+func (l *teamLookup) resolve(value string) (*Team, error) {
+	key := NormalizeName(value)
+	if key == "" {
+		return nil, errors.New("team cannot be empty")
+	}
+	if t := l.byName[key]; t != nil {
+		return t, nil
+	}
+	var prefixed *Team
+	for _, n := range l.full {
+		if strings.HasPrefix(n.name, key) {
+			if prefixed != nil {
+				return nil, fmt.Errorf("unknown team %q", value)
+			}
+			prefixed = n.team
+		}
+	}
+	if prefixed == nil {
+		return nil, fmt.Errorf("unknown team %q", value)
+	}
+	return prefixed, nil
+}
+
+// short returns the team whose short name is token, ignoring case.
+func (l *teamLookup) short(token string) *Team {
+	return l.byShort[strings.ToUpper(token)]
+}
+
+// positionLookup finds positions by their normalized names and the short
+// forms in positionAliases.
+type positionLookup struct {
+	byName  map[string]*ElementType
+	byShort map[string]*ElementType
+}
+
+func newPositionLookup(types []ElementType) *positionLookup {
+	l := &positionLookup{
+		byName:  make(map[string]*ElementType, 3*len(types)+len(positionAliases)),
+		byShort: make(map[string]*ElementType, len(positionAliases)),
+	}
+	for i := range types {
+		t := &types[i]
+		for _, name := range []string{t.SingularNameShort, t.SingularName, t.PluralName} {
+			if key := NormalizeName(name); key != "" {
+				if _, taken := l.byName[key]; !taken {
+					l.byName[key] = t
+				}
+			}
+		}
+	}
+	for alias, short := range positionAliases {
+		if t := l.byName[NormalizeName(short)]; t != nil {
+			l.byName[alias] = t
+			l.byShort[alias] = t
+		}
+	}
+	return l
+}
+
+func (l *positionLookup) resolve(value string) (*ElementType, error) {
+	if t := l.byName[NormalizeName(value)]; t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown position %q (expected GKP, DEF, MID or FWD)", value)
+}
+
+// short returns the position token abbreviates ("GK", "MID"), ignoring
+// case.
+func (l *positionLookup) short(token string) *ElementType {
+	return l.byShort[strings.ToLower(token)]
+}
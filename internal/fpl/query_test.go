@@ -0,0 +1,49 @@
+package fpl
+
+import "testing"
+
+var (
+	testTeams = []Team{
+		{ID: 1, Name: "Liverpool", ShortName: "LIV"},
+		{ID: 2, Name: "Newcastle", ShortName: "NEW"},
+		{ID: 3, Name: "Spurs", ShortName: "TOT"},
+		{ID: 4, Name: "Brighton", ShortName: "BHA"},
+	}
+	testTypes = []ElementType{
+		{ID: 1, SingularName: "Goalkeeper", SingularNameShort: "GKP", PluralName: "Goalkeepers"},
+		{ID: 2, SingularName: "Defender", SingularNameShort: "DEF", PluralName: "Defenders"},
+		{ID: 3, SingularName: "Midfielder", SingularNameShort: "MID", PluralName: "Midfielders"},
+		{ID: 4, SingularName: "Forward", SingularNameShort: "FWD", PluralName: "Forwards"},
+	}
+)
+
+func TestParsePlayerQuery(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want PlayerQuery
+	}{
+		{"Johnson TOT", PlayerQuery{Name: "Johnson", TeamID: 3}},
+		{"Gordon new", PlayerQuery{Name: "Gordon", TeamID: 2}},
+		{"team:LIV salah", PlayerQuery{Name: "salah", TeamID: 1}},
+		{"team:liverpool pos:mid salah", PlayerQuery{Name: "salah", TeamID: 1, ElementTypeID: 3}},
+		{"Raya pos:GK", PlayerQuery{Name: "Raya", ElementTypeID: 1}},
+		{"TOT", PlayerQuery{Name: "TOT"}},
+	}
+	for _, tc := range cases {
+		got, err := ParsePlayerQuery(tc.raw, testTeams, testTypes)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.raw, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q: got %+v want %+v", tc.raw, got, tc.want)
+		}
+	}
+
+	if _, err := ParsePlayerQuery("team:XYZ salah", testTeams, testTypes); err == nil {
+		t.Fatal("expected error for unknown team")
+	}
+	if _, err := ParsePlayerQuery("team:LIV team:NEW salah", testTeams, testTypes); err == nil {
+		t.Fatal("expected error for conflicting teams")
+	}
+}
@@ -0,0 +1,46 @@
+package fpl
+
+import "sort"
+
+const (
+	// MaxFreeTransfers is the most free transfers a manager can bank.
+	MaxFreeTransfers = 5
+	// TransferHitCost is the points deducted for each extra transfer.
+	TransferHitCost = 4
+)
+
+// SellingPrice is what a manager receives for a player bought at purchase
+// and now worth now (both in 0.1m): half of any rise, rounded down, and all
+// of any fall.
+func SellingPrice(purchase, now int) int {
+	if now <= purchase {
+		return now
+	}
+	return purchase + (now-purchase)/2
+}
+
+// FreeTransfers works out how many free transfers a manager has for the
+// gameweek after their last history row. One is gained each gameweek after
+// the first, up to MaxFreeTransfers; transfers spend them, and a wildcard or
+// free hit leaves the banked ones untouched.
+func FreeTransfers(h *EntryHistory) int {
+	rows := append([]EntryEvent(nil), h.Current...)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Event < rows[j].Event })
+	chips := make(map[int]string, len(h.Chips))
+	for _, c := range h.Chips {
+		chips[c.Event] = c.Name
+	}
+
+	free := 0
+	for i, row := range rows {
+		switch {
+		case i == 0:
+			// Transfers before a manager's first deadline are unlimited.
+		case chips[row.Event] == "wildcard" || chips[row.Event] == "freehit":
+		default:
+			free = max(0, free-row.EventTransfers)
+		}
+		free = min(MaxFreeTransfers, free+1)
+	}
+	return free
+}
@@ -0,0 +1,47 @@
+package fpl
+
+import "testing"
+
+func TestSellingPrice(t *testing.T) {
+	cases := []struct{ purchase, now, want int }{
+		{50, 50, 50},
+		{50, 51, 50},
+		{50, 52, 51},
+		{50, 55, 52},
+		{50, 47, 47},
+	}
+	for _, c := range cases {
+		if got := SellingPrice(c.purchase, c.now); got != c.want {
+			t.Fatalf("SellingPrice(%d, %d) = %d, want %d", c.purchase, c.now, got, c.want)
+		}
+	}
+}
+
+func TestFreeTransfers(t *testing.T) {
+	h := &EntryHistory{
+		Current: []EntryEvent{
+			{Event: 3, EventTransfers: 0},
+			{Event: 4, EventTransfers: 0},
+			{Event: 5, EventTransfers: 3},
+			{Event: 6, EventTransfers: 0},
+			{Event: 7, EventTransfers: 9},
+			{Event: 8, EventTransfers: 0},
+		},
+		Chips: []ChipPlay{{Name: "wildcard", Event: 7}},
+	}
+	// GW3 start: 1 for GW4, 2 for GW5, 3 spent so 1 for GW6, 2 for GW7,
+	// the wildcard keeps them so 3 for GW8, then 4 for GW9.
+	if got := FreeTransfers(h); got != 4 {
+		t.Fatalf("expected 4 free transfers, got %d", got)
+	}
+
+	for i := 9; i < 20; i++ {
+		h.Current = append(h.Current, EntryEvent{Event: i})
+	}
+	if got := FreeTransfers(h); got != MaxFreeTransfers {
+		t.Fatalf("expected free transfers to cap at %d, got %d", MaxFreeTransfers, got)
+	}
+	if got := FreeTransfers(&EntryHistory{}); got != 0 {
+		t.Fatalf("expected no free transfers without history, got %d", got)
+	}
+}
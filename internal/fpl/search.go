@@ -5,75 +5,195 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/lithammer/fuzzysearch/fuzzy"
 )
 
+// AmbiguityMargin is the confidence gap below which the top two distinct
+// players are considered too close to pick between.
+const AmbiguityMargin = 0.1
+
 // MatchSuggestion captures a fuzzy match candidate for a player name lookup.
 type MatchSuggestion struct {
 	Element  *Element
 	Alias    string
 	Distance int
+	// Confidence is 1 for an exact (normalized) match and falls towards 0 as
+	// the matched alias gets longer relative to the query.
+	Confidence float64
+}
+
+// AmbiguousMatchError is returned when a lookup cannot confidently choose
+// between several players.
+type AmbiguousMatchError struct {
+	Query       string
+	Suggestions []MatchSuggestion
+}
+
+func (e *AmbiguousMatchError) Error() string {
+	names := make([]string, 0, len(e.Suggestions))
+	for _, s := range e.Suggestions {
+		if s.Element == nil {
+			continue
+		}
+		names = append(names, fmt.Sprintf("%s (ID %d, %.0f%%)", s.Alias, s.Element.ID, s.Confidence*100))
+	}
+	return fmt.Sprintf("%q is ambiguous; candidates: %s", e.Query, strings.Join(names, ", "))
+}
+
+// IsAmbiguous reports whether the best two suggestions are within
+// AmbiguityMargin of each other.
+func IsAmbiguous(suggestions []MatchSuggestion) bool {
+	if len(suggestions) < 2 {
+		return false
+	}
+	return suggestions[0].Confidence-suggestions[1].Confidence < AmbiguityMargin
+}
+
+// AliasSource resolves user-maintained nicknames ("KDB", "Trent") to
+// element codes.
+type AliasSource interface {
+	LookupCode(name string) (code int, ok bool)
 }
 
 // FindPlayerByName runs a fuzzy search across common player name variants.
+// Both the query and the variants are folded with NormalizeName, so accents,
+// punctuation and spacing do not affect matching.
 func FindPlayerByName(query string, elements []Element) (*Element, []MatchSuggestion, error) {
+	ptrs := make([]*Element, len(elements))
+	for i := range elements {
+		ptrs[i] = &elements[i]
+	}
+	return newNameTargets(ptrs).find(query, nil)
+}
+
+// nameTargets holds the normalized name variants of a set of players so they
+// can be searched repeatedly without being rebuilt.
+type nameTargets struct {
+	targets    []string
+	candidates []nameCandidate
+	byCode     map[int]*Element
+	// exact maps each normalized variant to its candidates' indices, so an
+	// exact query skips fuzzy ranking.
+	exact map[string][]int
+}
+
+type nameCandidate struct {
+	element *Element
+	alias   string
+}
+
+func newNameTargets(elements []*Element) *nameTargets {
+	t := &nameTargets{}
+	for _, el := range elements {
+		variants := nameVariants(el)
+		t.add(el, variants, normalizeAll(variants))
+	}
+	return t
+}
+
+// add makes el searchable by its name variants, given with their normalized
+// forms so that one player's can be shared between several targets.
+func (t *nameTargets) add(el *Element, variants, normalized []string) {
+	if t.byCode == nil {
+		t.byCode = make(map[int]*Element)
+		t.exact = make(map[string][]int)
+	}
+	t.byCode[el.Code] = el
+	for i, alias := range variants {
+		t.exact[normalized[i]] = append(t.exact[normalized[i]], len(t.candidates))
+		t.targets = append(t.targets, normalized[i])
+		t.candidates = append(t.candidates, nameCandidate{element: el, alias: alias})
+	}
+}
+
+func normalizeAll(names []string) []string {
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = NormalizeName(name)
+	}
+	return out
+}
+
+// find resolves query against the targets.
+func (t *nameTargets) find(query string, aliases AliasSource) (*Element, []MatchSuggestion, error) {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return nil, nil, errors.New("player name cannot be empty")
 	}
+	normalizedQuery := NormalizeName(query)
+	if normalizedQuery == "" {
+		return nil, nil, fmt.Errorf("player name %q has no letters or digits", query)
+	}
+	if aliases != nil {
+		if code, ok := aliases.LookupCode(query); ok {
+			if el := t.byCode[code]; el != nil {
+				return el, []MatchSuggestion{{Element: el, Alias: query, Confidence: 1}}, nil
+			}
+		}
+	}
 
-	targets := make([]string, 0, len(elements)*2)
-	targetMap := make(map[string]*Element, len(elements)*2)
-
-	for i := range elements {
-		el := &elements[i]
-		for _, alias := range nameVariants(el) {
-			key := fmt.Sprintf("%s|%d", alias, el.ID)
-			if _, exists := targetMap[key]; exists {
+	if exact := t.exact[normalizedQuery]; len(exact) > 0 {
+		suggestions := make([]MatchSuggestion, 0, min(5, len(exact)))
+		seen := make(map[int]struct{}, len(exact))
+		for _, i := range exact {
+			c := t.candidates[i]
+			if _, dup := seen[c.element.ID]; dup || len(suggestions) == 5 {
 				continue
 			}
-			targets = append(targets, key)
-			targetMap[key] = el
+			seen[c.element.ID] = struct{}{}
+			suggestions = append(suggestions, MatchSuggestion{Element: c.element, Alias: c.alias, Confidence: 1})
 		}
+		return suggestions[0].Element, suggestions, nil
 	}
 
-	ranks := fuzzy.RankFindNormalizedFold(query, targets)
+	ranks := fuzzy.RankFind(normalizedQuery, t.targets)
 	if len(ranks) == 0 {
 		return nil, nil, fmt.Errorf("no players found matching %q", query)
 	}
 
-	sort.Slice(ranks, func(i, j int) bool {
+	sort.SliceStable(ranks, func(i, j int) bool {
+		ci := matchConfidence(ranks[i].Distance, ranks[i].Target)
+		cj := matchConfidence(ranks[j].Distance, ranks[j].Target)
+		if ci != cj {
+			return ci > cj
+		}
 		return ranks[i].Distance < ranks[j].Distance
 	})
 
-	best := ranks[0]
-	bestElement := targetMap[best.Target]
+	bestElement := t.candidates[ranks[0].OriginalIndex].element
 	suggestions := make([]MatchSuggestion, 0, min(5, len(ranks)))
 	seen := make(map[int]struct{}, len(ranks))
 	for _, r := range ranks {
 		if len(suggestions) == 5 {
 			break
 		}
-		el := targetMap[r.Target]
-		if el == nil {
+		c := t.candidates[r.OriginalIndex]
+		if _, dup := seen[c.element.ID]; dup {
 			continue
 		}
-		if _, dup := seen[el.ID]; dup {
-			continue
-		}
-		seen[el.ID] = struct{}{}
+		seen[c.element.ID] = struct{}{}
 
 		suggestions = append(suggestions, MatchSuggestion{
-			Element:  el,
-			Alias:    aliasFromKey(r.Target),
-			Distance: r.Distance,
+			Element:    c.element,
+			Alias:      c.alias,
+			Distance:   r.Distance,
+			Confidence: matchConfidence(r.Distance, r.Target),
 		})
 	}
 
 	return bestElement, suggestions, nil
 }
 
+func matchConfidence(distance int, target string) float64 {
+	length := utf8.RuneCountInString(target)
+	if length == 0 || distance >= length {
+		return 0
+	}
+	return 1 - float64(distance)/float64(length)
+}
+
 func nameVariants(el *Element) []string {
 	candidates := []string{
 		el.WebName,
@@ -89,24 +209,16 @@ func nameVariants(el *Element) []string {
 		if c == "" {
 			continue
 		}
-		cLower := strings.ToLower(c)
-		if _, ok := seen[cLower]; ok {
+		key := NormalizeName(c)
+		if _, ok := seen[key]; ok {
 			continue
 		}
-		seen[cLower] = struct{}{}
+		seen[key] = struct{}{}
 		unique = append(unique, c)
 	}
 	return unique
 }
 
-func aliasFromKey(key string) string {
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) == 0 {
-		return key
-	}
-	return parts[0]
-}
-
 func min(a, b int) int {
 	if a < b {
 		return a
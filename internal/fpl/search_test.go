@@ -48,3 +48,106 @@ func TestFindPlayerByNameDedupSuggestions(t *testing.T) {
 		t.Fatalf("expected player suggestions to be deduplicated, got %v", suggestions)
 	}
 }
+
+func TestNormalizeName(t *testing.T) {
+	cases := map[string]string{
+		"Ødegaard":        "odegaard",
+		"Guéhi":           "guehi",
+		"Mac Allister":    "macallister",
+		"O'Reilly":        "oreilly",
+		"Aït-Nouri":       "aitnouri",
+		"Fabiański":       "fabianski",
+		"Łukasz":          "lukasz",
+		"Gvardiol":        "gvardiol",
+		"Bruno Guimarães": "brunoguimaraes",
+	}
+	for in, want := range cases {
+		if got := NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFindPlayerByNameAccentInsensitive(t *testing.T) {
+	players := []Element{
+		{ID: 1, WebName: "Ødegaard", FirstName: "Martin", SecondName: "Ødegaard"},
+		{ID: 2, WebName: "Guéhi", FirstName: "Marc", SecondName: "Guéhi"},
+		{ID: 3, WebName: "Mac Allister", FirstName: "Alexis", SecondName: "Mac Allister"},
+		{ID: 4, WebName: "O'Reilly", FirstName: "Nico", SecondName: "O'Reilly"},
+		{ID: 5, WebName: "Aït-Nouri", FirstName: "Rayan", SecondName: "Aït-Nouri"},
+		{ID: 6, WebName: "Kovačić", FirstName: "Mateo", SecondName: "Kovačić"},
+		{ID: 7, WebName: "Szoboszlai", FirstName: "Dominik", SecondName: "Szoboszlai"},
+		{ID: 8, WebName: "Doku", FirstName: "Jérémy", SecondName: "Doku"},
+		{ID: 9, WebName: "Konaté", FirstName: "Ibrahima", SecondName: "Konaté"},
+		{ID: 10, WebName: "Gvardiol", FirstName: "Joško", SecondName: "Gvardiol"},
+	}
+
+	cases := map[string]int{
+		"odegaard":        1,
+		"guehi":           2,
+		"macallister":     3,
+		"mac allister":    3,
+		"oreilly":         4,
+		"o reilly":        4,
+		"ait-nouri":       5,
+		"aitnouri":        5,
+		"kovacic":         6,
+		"szoboszlai":      7,
+		"jeremy doku":     8,
+		"konate":          9,
+		"josko gvardiol":  10,
+		"Martin Ødegaard": 1,
+	}
+	for query, wantID := range cases {
+		player, suggestions, err := FindPlayerByName(query, players)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", query, err)
+			continue
+		}
+		if player.ID != wantID {
+			t.Errorf("%q: expected player %d, got %d", query, wantID, player.ID)
+		}
+		if suggestions[0].Element.ID != wantID || suggestions[0].Distance != 0 {
+			t.Errorf("%q: expected exact top suggestion, got %+v", query, suggestions[0])
+		}
+	}
+}
+
+func TestFindPlayerByNameConfidence(t *testing.T) {
+	players := []Element{
+		{ID: 1, WebName: "Gabriel", FirstName: "Gabriel", SecondName: "dos Santos Magalhães"},
+		{ID: 2, WebName: "G.Jesus", FirstName: "Gabriel", SecondName: "Fernando de Jesus"},
+		{ID: 3, WebName: "Saka", FirstName: "Bukayo", SecondName: "Saka"},
+	}
+
+	_, suggestions, err := FindPlayerByName("saka", players)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if suggestions[0].Confidence != 1 {
+		t.Fatalf("expected exact match confidence 1, got %v", suggestions[0].Confidence)
+	}
+	if IsAmbiguous(suggestions) {
+		t.Fatal("expected exact single match not to be ambiguous")
+	}
+
+	_, suggestions, err = FindPlayerByName("gab", players)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if suggestions[0].Confidence >= 1 || suggestions[0].Confidence <= 0 {
+		t.Fatalf("expected partial confidence, got %v", suggestions[0].Confidence)
+	}
+
+	johnsons := []Element{
+		{ID: 1, WebName: "Johnson", FirstName: "Ben", SecondName: "Johnson"},
+		{ID: 2, WebName: "Johnson", FirstName: "Brennan", SecondName: "Johnson"},
+	}
+	_, suggestions, err = FindPlayerByName("johnson", johnsons)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !IsAmbiguous(suggestions) {
+		t.Fatalf("expected two exact Johnsons to be ambiguous, got %+v", suggestions)
+	}
+}
@@ -7,11 +7,25 @@ type BootstrapStatic struct {
 	Elements     []Element     `json:"elements"`
 	Teams        []Team        `json:"teams"`
 	ElementTypes []ElementType `json:"element_types"`
+	Events       []Event       `json:"events"`
+}
+
+// Event is a gameweek.
+type Event struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	DeadlineTime *time.Time `json:"deadline_time"`
+	Finished     bool       `json:"finished"`
+	DataChecked  bool       `json:"data_checked"`
+	IsPrevious   bool       `json:"is_previous"`
+	IsCurrent    bool       `json:"is_current"`
+	IsNext       bool       `json:"is_next"`
 }
 
 // Element captures the player metadata needed for CLI output.
 type Element struct {
 	ID          int    `json:"id"`
+	Code        int    `json:"code"` // stable across seasons, unlike ID
 	WebName     string `json:"web_name"`
 	FirstName   string `json:"first_name"`
 	SecondName  string `json:"second_name"`
@@ -23,7 +37,36 @@ type Element struct {
 	TotalPoints int    `json:"total_points"`
 	Form        string `json:"form"`
 	ICTIndex    string `json:"ict_index"`
-	News        string `json:"news"`
+	// PointsPerGame, EPThis and EPNext (the API's expected points for the
+	// current and next gameweek) are decimal strings.
+	PointsPerGame string `json:"points_per_game"`
+	EPThis        string `json:"ep_this"`
+	EPNext        string `json:"ep_next"`
+	News          string `json:"news"`
+	// Status is "a" (available), "d" (doubtful), "i" (injured), "s" (suspended),
+	// "u" (unavailable) or "n" (not in squad).
+	Status string `json:"status"`
+	// ChanceOfPlayingThisRound and ChanceOfPlayingNextRound are percentages
+	// for the current and next gameweek, or nil when the player is not
+	// flagged.
+	ChanceOfPlayingThisRound *int `json:"chance_of_playing_this_round"`
+	ChanceOfPlayingNextRound *int `json:"chance_of_playing_next_round"`
+
+	// Season totals. The expected stats are decimal strings.
+	Minutes               int    `json:"minutes"`
+	Starts                int    `json:"starts"`
+	GoalsScored           int    `json:"goals_scored"`
+	Assists               int    `json:"assists"`
+	CleanSheets           int    `json:"clean_sheets"`
+	GoalsConceded         int    `json:"goals_conceded"`
+	Saves                 int    `json:"saves"`
+	Bonus                 int    `json:"bonus"`
+	BPS                   int    `json:"bps"`
+	YellowCards           int    `json:"yellow_cards"`
+	RedCards              int    `json:"red_cards"`
+	ExpectedGoals         string `json:"expected_goals"`
+	ExpectedAssists       string `json:"expected_assists"`
+	ExpectedGoalsConceded string `json:"expected_goals_conceded"`
 }
 
 // Team describes a Premier League club.
@@ -31,6 +74,11 @@ type Team struct {
 	ID        int    `json:"id"`
 	Name      string `json:"name"`
 	ShortName string `json:"short_name"`
+	// Strength ratings, roughly 1000-1400, split by venue.
+	StrengthAttackHome  int `json:"strength_attack_home"`
+	StrengthAttackAway  int `json:"strength_attack_away"`
+	StrengthDefenceHome int `json:"strength_defence_home"`
+	StrengthDefenceAway int `json:"strength_defence_away"`
 }
 
 // ElementType maps the player's position (e.g. Forward, Midfielder).
@@ -38,31 +86,252 @@ type ElementType struct {
 	ID                int    `json:"id"`
 	SingularName      string `json:"singular_name"`
 	SingularNameShort string `json:"singular_name_short"`
+	PluralName        string `json:"plural_name"`
 }
 
 // PlayerSummary is returned by /element-summary/{id}/.
 type PlayerSummary struct {
-	History []HistoryEntry `json:"history"`
+	Fixtures []SummaryFixture `json:"fixtures"`
+	History  []HistoryEntry   `json:"history"`
+}
+
+// SummaryFixture is an upcoming fixture from the player's team's point of
+// view. Event is nil while the fixture is unscheduled.
+type SummaryFixture struct {
+	ID          int        `json:"id"`
+	Event       *int       `json:"event"`
+	TeamH       int        `json:"team_h"`
+	TeamA       int        `json:"team_a"`
+	IsHome      bool       `json:"is_home"`
+	Difficulty  int        `json:"difficulty"`
+	Finished    bool       `json:"finished"`
+	KickoffTime *time.Time `json:"kickoff_time"`
 }
 
 // HistoryEntry represents the stats for a single gameweek.
 type HistoryEntry struct {
-	Round         int        `json:"round"`
-	OpponentTeam  int        `json:"opponent_team"`
-	WasHome       bool       `json:"was_home"`
-	TotalPoints   int        `json:"total_points"`
-	Minutes       int        `json:"minutes"`
-	GoalsScored   int        `json:"goals_scored"`
-	Assists       int        `json:"assists"`
-	CleanSheets   int        `json:"clean_sheets"`
-	GoalsConceded int        `json:"goals_conceded"`
-	YellowCards   int        `json:"yellow_cards"`
-	RedCards      int        `json:"red_cards"`
-	BPS           int        `json:"bps"`
-	Influence     string     `json:"influence"`
-	Creativity    string     `json:"creativity"`
-	Threat        string     `json:"threat"`
-	ICTIndex      string     `json:"ict_index"`
-	Value         int        `json:"value"`
-	KickoffTime   *time.Time `json:"kickoff_time"`
+	Round                    int        `json:"round"`
+	Fixture                  int        `json:"fixture"`
+	OpponentTeam             int        `json:"opponent_team"`
+	WasHome                  bool       `json:"was_home"`
+	TotalPoints              int        `json:"total_points"`
+	Minutes                  int        `json:"minutes"`
+	Starts                   int        `json:"starts"`
+	GoalsScored              int        `json:"goals_scored"`
+	Assists                  int        `json:"assists"`
+	CleanSheets              int        `json:"clean_sheets"`
+	GoalsConceded            int        `json:"goals_conceded"`
+	YellowCards              int        `json:"yellow_cards"`
+	RedCards                 int        `json:"red_cards"`
+	BPS                      int        `json:"bps"`
+	Influence                string     `json:"influence"`
+	Creativity               string     `json:"creativity"`
+	Threat                   string     `json:"threat"`
+	ICTIndex                 string     `json:"ict_index"`
+	ExpectedGoals            string     `json:"expected_goals"`
+	ExpectedAssists          string     `json:"expected_assists"`
+	ExpectedGoalInvolvements string     `json:"expected_goal_involvements"`
+	ExpectedGoalsConceded    string     `json:"expected_goals_conceded"`
+	Value                    int        `json:"value"`
+	KickoffTime              *time.Time `json:"kickoff_time"`
+}
+
+// Entry is a manager's team as returned by /entry/{id}/.
+type Entry struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	PlayerFirstName   string `json:"player_first_name"`
+	PlayerLastName    string `json:"player_last_name"`
+	StartedEvent      int    `json:"started_event"`
+	CurrentEvent      int    `json:"current_event"`
+	SummaryOverall    int    `json:"summary_overall_points"`
+	LastDeadlineBank  int    `json:"last_deadline_bank"`
+	LastDeadlineValue int    `json:"last_deadline_value"`
+}
+
+// EntryHistory is returned by /entry/{id}/history/.
+type EntryHistory struct {
+	Current []EntryEvent `json:"current"`
+	Chips   []ChipPlay   `json:"chips"`
+}
+
+// EntryEvent is a manager's gameweek summary. Bank and Value are in 0.1m.
+type EntryEvent struct {
+	Event              int `json:"event"`
+	Points             int `json:"points"`
+	TotalPoints        int `json:"total_points"`
+	Bank               int `json:"bank"`
+	Value              int `json:"value"`
+	EventTransfers     int `json:"event_transfers"`
+	EventTransfersCost int `json:"event_transfers_cost"`
+	PointsOnBench      int `json:"points_on_bench"`
+}
+
+// ChipPlay records a chip ("wildcard", "freehit", "bboost", "3xc") and the
+// gameweek it was played in.
+type ChipPlay struct {
+	Name  string `json:"name"`
+	Event int    `json:"event"`
+}
+
+// EntryPicks is returned by /entry/{id}/event/{gw}/picks/.
+type EntryPicks struct {
+	ActiveChip   string     `json:"active_chip"`
+	EntryHistory EntryEvent `json:"entry_history"`
+	Picks        []Pick     `json:"picks"`
+	// AutomaticSubs lists the substitutions made once the gameweek's
+	// matches finished.
+	AutomaticSubs []AutomaticSub `json:"automatic_subs"`
+}
+
+// AutomaticSub is a bench player brought on for a starter who did not play.
+type AutomaticSub struct {
+	ElementIn  int `json:"element_in"`
+	ElementOut int `json:"element_out"`
+	Event      int `json:"event"`
+}
+
+// Pick is one squad slot. Positions 1-11 start and 12-15 are the bench in
+// substitution order.
+type Pick struct {
+	Element       int  `json:"element"`
+	Position      int  `json:"position"`
+	Multiplier    int  `json:"multiplier"`
+	IsCaptain     bool `json:"is_captain"`
+	IsViceCaptain bool `json:"is_vice_captain"`
+}
+
+// LeagueStandings is one page of /leagues-classic/{id}/standings/.
+type LeagueStandings struct {
+	League    League        `json:"league"`
+	Standings StandingsPage `json:"standings"`
+}
+
+// League identifies a classic league.
+type League struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// StandingsPage holds up to 50 standings; HasNext is set when more follow.
+type StandingsPage struct {
+	HasNext bool       `json:"has_next"`
+	Page    int        `json:"page"`
+	Results []Standing `json:"results"`
+}
+
+// Standing is one manager's place in a classic league.
+type Standing struct {
+	Entry      int    `json:"entry"`
+	EntryName  string `json:"entry_name"`
+	PlayerName string `json:"player_name"`
+	Rank       int    `json:"rank"`
+	LastRank   int    `json:"last_rank"`
+	Total      int    `json:"total"`
+	EventTotal int    `json:"event_total"`
+}
+
+// Transfer is one completed transfer from /entry/{id}/transfers/. Costs are
+// the prices paid and received, in 0.1m.
+type Transfer struct {
+	ElementIn      int       `json:"element_in"`
+	ElementInCost  int       `json:"element_in_cost"`
+	ElementOut     int       `json:"element_out"`
+	ElementOutCost int       `json:"element_out_cost"`
+	Event          int       `json:"event"`
+	Time           time.Time `json:"time"`
+}
+
+// Fixture is a match from /fixtures/. Event is nil while the fixture is
+// unscheduled and the scores are nil until it kicks off. Difficulty ratings
+// run from 1 (easiest) to 5 for the named side.
+type Fixture struct {
+	ID              int        `json:"id"`
+	Event           *int       `json:"event"`
+	TeamH           int        `json:"team_h"`
+	TeamA           int        `json:"team_a"`
+	TeamHScore      *int       `json:"team_h_score"`
+	TeamAScore      *int       `json:"team_a_score"`
+	TeamHDifficulty int        `json:"team_h_difficulty"`
+	TeamADifficulty int        `json:"team_a_difficulty"`
+	KickoffTime     *time.Time `json:"kickoff_time"`
+	Started         bool       `json:"started"`
+	Finished        bool       `json:"finished"`
+	// FinishedProvisional is set at full time, before the result is
+	// confirmed and Finished follows.
+	FinishedProvisional bool `json:"finished_provisional"`
+	Minutes             int  `json:"minutes"`
+	// Stats lists match events such as goals, assists and BPS by side.
+	Stats []FixtureStat `json:"stats"`
+}
+
+// FixtureStat is one statistic from a fixture, such as "goals_scored" or
+// "bps", for each side's players.
+type FixtureStat struct {
+	Identifier string      `json:"identifier"`
+	Home       []StatValue `json:"h"`
+	Away       []StatValue `json:"a"`
+}
+
+// StatValue is one player's value for a FixtureStat.
+type StatValue struct {
+	Element int `json:"element"`
+	Value   int `json:"value"`
+}
+
+// Live is returned by /event/{gw}/live/: every player's gameweek stats so
+// far, summed over a double gameweek's fixtures.
+type Live struct {
+	Elements []LiveElement `json:"elements"`
+}
+
+// LiveElement is one player's live gameweek.
+type LiveElement struct {
+	ID    int       `json:"id"`
+	Stats LiveStats `json:"stats"`
+	// Explain breaks the points down fixture by fixture.
+	Explain []FixtureExplain `json:"explain"`
+}
+
+// FixtureExplain is how a player scored in one fixture.
+type FixtureExplain struct {
+	Fixture int           `json:"fixture"`
+	Stats   []ExplainStat `json:"stats"`
+}
+
+// ExplainStat is the points one statistic, such as "minutes" or "bonus",
+// earned: Value of it scored Points.
+type ExplainStat struct {
+	Identifier string `json:"identifier"`
+	Points     int    `json:"points"`
+	Value      int    `json:"value"`
+}
+
+// LiveStats are a player's gameweek totals. Bonus stays zero until it is
+// confirmed after each match.
+type LiveStats struct {
+	Minutes         int  `json:"minutes"`
+	GoalsScored     int  `json:"goals_scored"`
+	Assists         int  `json:"assists"`
+	CleanSheets     int  `json:"clean_sheets"`
+	GoalsConceded   int  `json:"goals_conceded"`
+	OwnGoals        int  `json:"own_goals"`
+	PenaltiesSaved  int  `json:"penalties_saved"`
+	PenaltiesMissed int  `json:"penalties_missed"`
+	YellowCards     int  `json:"yellow_cards"`
+	RedCards        int  `json:"red_cards"`
+	Saves           int  `json:"saves"`
+	Bonus           int  `json:"bonus"`
+	BPS             int  `json:"bps"`
+	TotalPoints     int  `json:"total_points"`
+	InDreamteam     bool `json:"in_dreamteam"`
+}
+
+// Stats indexes the live elements by player ID.
+func (l *Live) Stats() map[int]LiveStats {
+	stats := make(map[int]LiveStats, len(l.Elements))
+	for _, el := range l.Elements {
+		stats[el.ID] = el.Stats
+	}
+	return stats
 }
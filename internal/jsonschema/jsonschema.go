@@ -0,0 +1,129 @@
+// Package jsonschema derives JSON Schema documents from Go types using the
+// same field names encoding/json would produce.
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect emitted by Generate.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema is a JSON Schema document or sub-schema.
+type Schema map[string]any
+
+// Generate builds a schema for v, which should be a struct value or pointer.
+func Generate(v any) Schema {
+	s := forType(reflect.TypeOf(v))
+	s["$schema"] = Draft
+	return s
+}
+
+func forType(t reflect.Type) Schema {
+	if t.Kind() == reflect.Pointer {
+		inner := forType(t.Elem())
+		return nullable(inner)
+	}
+	if t == timeType {
+		return Schema{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		// encoding/json writes nil slices as null.
+		return Schema{"type": []string{"array", "null"}, "items": forType(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": forType(t.Elem())}
+	case reflect.Struct:
+		return forStruct(t)
+	}
+	return Schema{}
+}
+
+func forStruct(t reflect.Type) Schema {
+	properties := Schema{}
+	required := []string{}
+	addFields(t, properties, &required)
+	// Additional properties stay allowed so that adding a field never breaks
+	// consumers validating against an older document.
+	return Schema{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+func addFields(t reflect.Type, properties Schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addFields(ft, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = forType(field.Type)
+		if !omitEmpty {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return "", false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, false
+}
+
+func nullable(s Schema) Schema {
+	switch typ := s["type"].(type) {
+	case string:
+		s["type"] = []string{typ, "null"}
+	case []string:
+		for _, existing := range typ {
+			if existing == "null" {
+				return s
+			}
+		}
+		s["type"] = append(typ, "null")
+	}
+	return s
+}
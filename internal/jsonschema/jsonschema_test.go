@@ -0,0 +1,53 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type inner struct {
+	Value float64 `json:"value"`
+}
+
+type sample struct {
+	Name     string     `json:"name"`
+	Count    int        `json:"count,omitempty"`
+	Tags     []string   `json:"tags"`
+	When     *time.Time `json:"when"`
+	Nested   inner      `json:"nested"`
+	Ignored  string     `json:"-"`
+	internal string
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(sample{})
+	if s["$schema"] != Draft {
+		t.Fatalf("expected draft marker, got %v", s["$schema"])
+	}
+
+	props := s["properties"].(Schema)
+	if _, ok := props["Ignored"]; ok {
+		t.Fatal("expected json:\"-\" fields to be skipped")
+	}
+	if _, ok := props["internal"]; ok {
+		t.Fatal("expected unexported fields to be skipped")
+	}
+	if got := props["name"].(Schema)["type"]; got != "string" {
+		t.Fatalf("expected string name, got %v", got)
+	}
+	if got := props["when"].(Schema); !reflect.DeepEqual(got["type"], []string{"string", "null"}) || got["format"] != "date-time" {
+		t.Fatalf("expected nullable date-time, got %v", got)
+	}
+	if got := props["tags"].(Schema)["items"].(Schema)["type"]; got != "string" {
+		t.Fatalf("expected string items, got %v", got)
+	}
+	if got := props["nested"].(Schema)["properties"].(Schema)["value"].(Schema)["type"]; got != "number" {
+		t.Fatalf("expected nested number, got %v", got)
+	}
+
+	required := s["required"].([]string)
+	if !reflect.DeepEqual(required, []string{"name", "tags", "when", "nested"}) {
+		t.Fatalf("unexpected required fields %v", required)
+	}
+}
@@ -0,0 +1,58 @@
+package optimize
+
+import "sort"
+
+// Lineup is a starting XI and bench chosen from a squad.
+type Lineup struct {
+	// Starters and Bench are each ordered by position, then descending
+	// score.
+	Starters  []Candidate
+	Bench     []Candidate
+	Formation Formation
+	// Score is the starters' total.
+	Score float64
+}
+
+// BestLineup picks the starting XI with the highest total score from squad
+// under any of the rules' formations. It reports false when the squad cannot
+// field any of them. Ties keep the earlier formation and, within a position,
+// the player listed first.
+func BestLineup(squad []Candidate, rules Rules) (Lineup, bool) {
+	var byPos [5][]Candidate
+	for _, c := range squad {
+		if c.Position >= GKP && c.Position <= FWD {
+			byPos[c.Position] = append(byPos[c.Position], c)
+		}
+	}
+	for pos := range byPos {
+		sort.SliceStable(byPos[pos], func(i, j int) bool { return byPos[pos][i].Score > byPos[pos][j].Score })
+	}
+
+	var best Lineup
+	found := false
+	for _, f := range rules.Formations {
+		score, fits := 0.0, true
+		for pos := GKP; pos <= FWD; pos++ {
+			n := f.starters(pos)
+			if n > len(byPos[pos]) {
+				fits = false
+				break
+			}
+			for _, c := range byPos[pos][:n] {
+				score += c.Score
+			}
+		}
+		if fits && (!found || score > best.Score) {
+			best, found = Lineup{Formation: f, Score: score}, true
+		}
+	}
+	if !found {
+		return Lineup{}, false
+	}
+	for pos := GKP; pos <= FWD; pos++ {
+		n := best.Formation.starters(pos)
+		best.Starters = append(best.Starters, byPos[pos][:n]...)
+		best.Bench = append(best.Bench, byPos[pos][n:]...)
+	}
+	return best, true
+}
@@ -0,0 +1,45 @@
+package optimize
+
+import "testing"
+
+func TestBestLineup(t *testing.T) {
+	squad := []Candidate{
+		{ID: 1, Position: GKP, Score: 2},
+		{ID: 2, Position: GKP, Score: 6},
+		{ID: 3, Position: DEF, Score: 1},
+		{ID: 4, Position: DEF, Score: 2},
+		{ID: 5, Position: DEF, Score: 2},
+		{ID: 6, Position: DEF, Score: 0},
+		{ID: 7, Position: DEF, Score: 1},
+		{ID: 8, Position: MID, Score: 12},
+		{ID: 9, Position: MID, Score: 5},
+		{ID: 10, Position: MID, Score: 3},
+		{ID: 11, Position: MID, Score: 8},
+		{ID: 12, Position: MID, Score: 2},
+		{ID: 13, Position: FWD, Score: 9},
+		{ID: 14, Position: FWD, Score: 6},
+		{ID: 15, Position: FWD, Score: 1},
+	}
+	lineup, ok := BestLineup(squad, DefaultRules())
+	if !ok {
+		t.Fatal("expected a lineup")
+	}
+	// 3-5-2: 6 + (2+2+1) + (12+8+5+3+2) + (9+6).
+	if lineup.Formation != (Formation{DEF: 3, MID: 5, FWD: 2}) || lineup.Score != 56 {
+		t.Fatalf("unexpected lineup %v scoring %.0f", lineup.Formation, lineup.Score)
+	}
+	if len(lineup.Starters) != 11 || len(lineup.Bench) != 4 {
+		t.Fatalf("expected 11 starters and 4 on the bench, got %d and %d", len(lineup.Starters), len(lineup.Bench))
+	}
+	if lineup.Starters[0].ID != 2 || lineup.Bench[0].ID != 1 {
+		t.Fatalf("expected the better keeper to start, got %+v", lineup.Starters[0])
+	}
+	// Tied defenders keep squad order: 3 starts ahead of 7.
+	if lineup.Starters[3].ID != 3 {
+		t.Fatalf("expected defender 3 to start, got %d", lineup.Starters[3].ID)
+	}
+
+	if _, ok := BestLineup(squad[:3], DefaultRules()); ok {
+		t.Fatal("expected no lineup from three players")
+	}
+}
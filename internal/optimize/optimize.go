@@ -0,0 +1,685 @@
+// Package optimize picks FPL squads exactly under the game's squad rules.
+//
+// Ignoring the club limit, the best squad decomposes by position: a dynamic
+// program over (starters, bench players, cost) gives each position's best
+// value for every exact spend, and max-plus convolution combines the four
+// positions for each legal formation. The club limit is then enforced by
+// best-first branch-and-bound on that relaxation, tightened with Lagrangian
+// penalties on club counts, so the result is optimal rather than greedy.
+package optimize
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Element type IDs as used by the FPL API.
+const (
+	GKP = 1
+	DEF = 2
+	MID = 3
+	FWD = 4
+)
+
+// MaxNodes bounds the relaxations solved during the search. Real FPL data
+// needs far fewer; hitting the limit means the inputs are pathological.
+const MaxNodes = 20000
+
+// ErrInfeasible is returned when no squad satisfies the rules, locks and
+// exclusions.
+var ErrInfeasible = errors.New("no squad satisfies the budget, club limit, locks and exclusions")
+
+var negInf = math.Inf(-1)
+
+// Candidate is a player the solver may pick.
+type Candidate struct {
+	ID       int
+	Team     int
+	Position int     // element type, GKP to FWD
+	Cost     int     // tenths of £1m, as in Element.NowCost
+	Score    float64 // objective value when the player starts
+}
+
+// Formation is the number of starting defenders, midfielders and forwards.
+// Exactly one goalkeeper always starts.
+type Formation struct {
+	DEF, MID, FWD int
+}
+
+func (f Formation) String() string {
+	return fmt.Sprintf("%d-%d-%d", f.DEF, f.MID, f.FWD)
+}
+
+func (f Formation) starters(pos int) int {
+	switch pos {
+	case GKP:
+		return 1
+	case DEF:
+		return f.DEF
+	case MID:
+		return f.MID
+	case FWD:
+		return f.FWD
+	}
+	return 0
+}
+
+// Rules are the squad constraints.
+type Rules struct {
+	Budget     int    // tenths of £1m
+	Squad      [5]int // squad size per element type; index 0 is unused
+	MaxPerClub int
+	Formations []Formation
+	// BenchWeight is the share of a bench player's score that counts towards
+	// the objective, reflecting the chance they come on as a substitute.
+	BenchWeight float64
+}
+
+// DefaultRules returns the FPL rules: £100m, 2 GKP, 5 DEF, 5 MID, 3 FWD, at
+// most three players per club and any formation with at least three
+// defenders, two midfielders and one forward.
+func DefaultRules() Rules {
+	var formations []Formation
+	for d := 3; d <= 5; d++ {
+		for m := 2; m <= 5; m++ {
+			if f := 10 - d - m; f >= 1 && f <= 3 {
+				formations = append(formations, Formation{DEF: d, MID: m, FWD: f})
+			}
+		}
+	}
+	return Rules{
+		Budget:      1000,
+		Squad:       [5]int{0, 2, 5, 5, 3},
+		MaxPerClub:  3,
+		Formations:  formations,
+		BenchWeight: 0.1,
+	}
+}
+
+func (r Rules) squadSize() int {
+	return r.Squad[GKP] + r.Squad[DEF] + r.Squad[MID] + r.Squad[FWD]
+}
+
+// Pick is a selected player.
+type Pick struct {
+	Candidate
+	Starter bool
+}
+
+// Result is an optimal squad.
+type Result struct {
+	// Picks lists starters then bench, each by position and descending score.
+	Picks     []Pick
+	Formation Formation
+	Cost      int
+	// Objective is the starters' score plus BenchWeight times the bench's.
+	Objective float64
+	XIScore   float64
+	// Nodes is the number of relaxations solved.
+	Nodes int
+}
+
+// Solve returns the squad maximizing the objective. Locked players must be
+// in the squad and excluded players may not be.
+func Solve(cands []Candidate, rules Rules, locked, excluded []int) (*Result, error) {
+	if rules.BenchWeight < 0 || rules.BenchWeight > 1 {
+		return nil, fmt.Errorf("bench weight must be between 0 and 1, got %g", rules.BenchWeight)
+	}
+	if rules.MaxPerClub < 1 {
+		return nil, errors.New("club limit must be at least 1")
+	}
+	if len(rules.Formations) == 0 {
+		return nil, errors.New("at least one formation is required")
+	}
+	for _, f := range rules.Formations {
+		for pos := GKP; pos <= FWD; pos++ {
+			if n := f.starters(pos); n < 0 || n > rules.Squad[pos] {
+				return nil, fmt.Errorf("formation %s does not fit a squad of %v", f, rules.Squad[1:])
+			}
+		}
+	}
+
+	banned := make(map[int]bool, len(excluded))
+	for _, id := range excluded {
+		banned[id] = true
+	}
+	byID := make(map[int]Candidate, len(cands))
+	for _, c := range cands {
+		if c.Position < GKP || c.Position > FWD {
+			return nil, fmt.Errorf("player %d has unknown position %d", c.ID, c.Position)
+		}
+		if !banned[c.ID] {
+			byID[c.ID] = c
+		}
+	}
+
+	forced := make(map[int]bool, len(locked))
+	perPos := [5]int{}
+	perClub := map[int]int{}
+	for _, id := range locked {
+		c, ok := byID[id]
+		if !ok {
+			if banned[id] {
+				return nil, fmt.Errorf("player %d is both locked and excluded", id)
+			}
+			return nil, fmt.Errorf("locked player %d is not a candidate", id)
+		}
+		if forced[id] {
+			continue
+		}
+		forced[id] = true
+		perPos[c.Position]++
+		perClub[c.Team]++
+		if perPos[c.Position] > rules.Squad[c.Position] {
+			return nil, fmt.Errorf("too many locked players in position %d (squad allows %d)", c.Position, rules.Squad[c.Position])
+		}
+		if perClub[c.Team] > rules.MaxPerClub {
+			return nil, fmt.Errorf("too many locked players from team %d (limit %d)", c.Team, rules.MaxPerClub)
+		}
+	}
+
+	s := &solver{rules: rules}
+	for pos := GKP; pos <= FWD; pos++ {
+		var pool []Candidate
+		for _, c := range byID {
+			if c.Position == pos {
+				pool = append(pool, c)
+			}
+		}
+		s.pools[pos] = prune(pool, forced, rules.Squad[pos]+rules.squadSize()/rules.MaxPerClub)
+	}
+	return s.search(forced)
+}
+
+// prune drops players that can always be swapped for a better one. A player
+// is dominated by another in the same position that costs no more and scores
+// at least as much; with dominators from `need` distinct clubs, any squad
+// containing the player has one that is unpicked and whose club has room, so
+// removing the player never loses the optimum.
+func prune(pool []Candidate, forced map[int]bool, need int) []Candidate {
+	sort.Slice(pool, func(i, j int) bool { return pool[i].ID < pool[j].ID })
+	kept := pool[:0:0]
+	for _, x := range pool {
+		if forced[x.ID] {
+			kept = append(kept, x)
+			continue
+		}
+		clubs := make(map[int]struct{})
+		for _, y := range pool {
+			if y.ID != x.ID && dominates(y, x) {
+				clubs[y.Team] = struct{}{}
+			}
+		}
+		if len(clubs) < need {
+			kept = append(kept, x)
+		}
+	}
+	return kept
+}
+
+// dominates is a strict order: ties on cost and score fall back to ID so two
+// identical players never remove each other.
+func dominates(y, x Candidate) bool {
+	if y.Cost > x.Cost || y.Score < x.Score {
+		return false
+	}
+	return y.Cost < x.Cost || y.Score > x.Score || y.ID < x.ID
+}
+
+// penaltyIterations bounds the subgradient steps used to tune the club
+// penalties at the root.
+const penaltyIterations = 40
+
+type solver struct {
+	rules Rules
+	pools [5][]Candidate
+	clubs []int
+	// penalty is a Lagrange multiplier per club: relaxations charge it for
+	// every player picked from the club and refund MaxPerClub times it, which
+	// keeps them upper bounds while steering them towards legal squads.
+	penalty map[int]float64
+	best    *Result
+	nodes   int
+}
+
+type node struct {
+	forced map[int]bool
+	banned map[int]bool
+	bound  float64
+	// sol is an over-full relaxed squad to branch on.
+	sol *Result
+}
+
+func (s *solver) search(forced map[int]bool) (*Result, error) {
+	seen := map[int]bool{}
+	for pos := GKP; pos <= FWD; pos++ {
+		for _, c := range s.pools[pos] {
+			if !seen[c.Team] {
+				seen[c.Team] = true
+				s.clubs = append(s.clubs, c.Team)
+			}
+		}
+	}
+	sort.Ints(s.clubs)
+
+	root := &node{forced: forced, banned: map[int]bool{}}
+	if r, _ := s.relax(root, nil); r == nil {
+		return nil, ErrInfeasible
+	}
+	s.tunePenalties(root)
+
+	queue := &nodeQueue{}
+	if s.evaluate(root) {
+		heap.Push(queue, root)
+	}
+	for queue.Len() > 0 {
+		n := heap.Pop(queue).(*node)
+		if !s.improves(n.bound) {
+			// Best-first: no remaining node can beat the incumbent.
+			break
+		}
+		if s.nodes >= MaxNodes {
+			return nil, fmt.Errorf("search stopped after %d nodes without proving optimality", MaxNodes)
+		}
+		team, _ := s.overfullClub(n.sol)
+		for _, child := range s.branch(n, team) {
+			if s.evaluate(child) {
+				heap.Push(queue, child)
+			}
+		}
+	}
+	if s.best == nil {
+		return nil, ErrInfeasible
+	}
+	s.best.Nodes = s.nodes
+	return s.best, nil
+}
+
+// tunePenalties runs subgradient descent on the Lagrangian bound at the root
+// and keeps the multipliers giving the tightest bound.
+func (s *solver) tunePenalties(root *node) {
+	penalty := map[int]float64{}
+	s.penalty = map[int]float64{}
+	bestBound := math.Inf(1)
+	theta, stall := 1.0, 0
+	for it := 0; it < penaltyIterations; it++ {
+		r, value := s.relax(root, penalty)
+		if r == nil {
+			return
+		}
+		s.offer(r)
+		bound := value + s.refund(penalty)
+		if bound < bestBound-1e-9 {
+			bestBound = bound
+			s.penalty = copyPenalty(penalty)
+			stall = 0
+		} else if stall++; stall >= 3 {
+			theta /= 2
+			stall = 0
+		}
+
+		counts := clubCounts(r)
+		norm := 0.0
+		for _, club := range s.clubs {
+			if g := float64(counts[club] - s.rules.MaxPerClub); g > 0 || penalty[club] > 0 {
+				norm += g * g
+			}
+		}
+		gap := 0.05 * math.Max(1, math.Abs(bound))
+		if s.best != nil {
+			gap = bound - s.best.Objective
+		}
+		if norm == 0 || gap <= 1e-9 {
+			return
+		}
+		step := theta * gap / norm
+		for _, club := range s.clubs {
+			penalty[club] = math.Max(0, penalty[club]+step*float64(counts[club]-s.rules.MaxPerClub))
+		}
+	}
+}
+
+// evaluate bounds a node, records any legal squad it finds and reports
+// whether the node still needs branching.
+func (s *solver) evaluate(n *node) bool {
+	r, value := s.relax(n, s.penalty)
+	if r == nil {
+		return false
+	}
+	n.bound = value + s.refund(s.penalty)
+	s.offer(r)
+	if !s.improves(n.bound) {
+		return false
+	}
+	if _, over := s.overfullClub(r); over {
+		n.sol = r
+		return true
+	}
+	// The penalized squad is legal but its bound may be loose; the plain
+	// relaxation is exact whenever it is legal too.
+	r, value = s.relax(n, nil)
+	n.bound = math.Min(n.bound, value)
+	s.offer(r)
+	if !s.improves(n.bound) {
+		return false
+	}
+	n.sol = r
+	return true
+}
+
+func (s *solver) refund(penalty map[int]float64) float64 {
+	total := 0.0
+	for _, p := range penalty {
+		total += p
+	}
+	return total * float64(s.rules.MaxPerClub)
+}
+
+// offer keeps r as the incumbent if it is legal and better.
+func (s *solver) offer(r *Result) {
+	if _, over := s.overfullClub(r); over {
+		return
+	}
+	if s.best == nil || r.Objective > s.best.Objective {
+		s.best = r
+	}
+}
+
+func (s *solver) improves(bound float64) bool {
+	if s.best == nil {
+		return true
+	}
+	return bound > s.best.Objective+1e-9*math.Max(1, math.Abs(s.best.Objective))
+}
+
+func clubCounts(r *Result) map[int]int {
+	counts := map[int]int{}
+	for _, p := range r.Picks {
+		counts[p.Team]++
+	}
+	return counts
+}
+
+func copyPenalty(m map[int]float64) map[int]float64 {
+	out := make(map[int]float64, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func (s *solver) overfullClub(r *Result) (int, bool) {
+	counts := map[int]int{}
+	for _, p := range r.Picks {
+		counts[p.Team]++
+		if counts[p.Team] > s.rules.MaxPerClub {
+			return p.Team, true
+		}
+	}
+	return 0, false
+}
+
+// branch partitions the node on the over-full club's unforced picks q1..qr:
+// child i bans qi and forces q1..q(i-1). Every legal squad in the node drops
+// at least one of the first MaxPerClub-forced+1 of them, so the children
+// cover it exactly once.
+func (s *solver) branch(n *node, team int) []*node {
+	forcedInClub := 0
+	var free []Candidate
+	for _, p := range n.sol.Picks {
+		if p.Team != team {
+			continue
+		}
+		if n.forced[p.ID] {
+			forcedInClub++
+		} else {
+			free = append(free, p.Candidate)
+		}
+	}
+	// Try dropping the weakest pick first; it is the most likely branch.
+	sort.Slice(free, func(i, j int) bool { return free[i].Score < free[j].Score })
+
+	var children []*node
+	for i := 0; i < len(free) && forcedInClub+i <= s.rules.MaxPerClub; i++ {
+		child := &node{forced: copySet(n.forced), banned: copySet(n.banned)}
+		child.banned[free[i].ID] = true
+		for _, c := range free[:i] {
+			child.forced[c.ID] = true
+		}
+		children = append(children, child)
+	}
+	return children
+}
+
+func copySet(m map[int]bool) map[int]bool {
+	out := make(map[int]bool, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// relax solves the node ignoring the club limit, charging penalty[club] for
+// each player picked. It returns the squad, whose Objective is the unpenalized
+// value, and the penalized value; the squad is nil when even the relaxation
+// is infeasible.
+func (s *solver) relax(n *node, penalty map[int]float64) (*Result, float64) {
+	s.nodes++
+	budget := s.rules.Budget
+	var tables [5]*posTable
+	for pos := GKP; pos <= FWD; pos++ {
+		var pool []Candidate
+		var forced []bool
+		for _, c := range s.pools[pos] {
+			if !n.banned[c.ID] {
+				pool = append(pool, c)
+				forced = append(forced, n.forced[c.ID])
+			}
+		}
+		tables[pos] = solvePosition(pool, forced, s.rules.Squad[pos], budget, s.rules.BenchWeight, penalty)
+	}
+
+	var best *Result
+	bestValue := negInf
+	for _, f := range s.rules.Formations {
+		total, splits := tables[GKP].best[f.starters(GKP)], [][]int(nil)
+		for pos := DEF; pos <= FWD; pos++ {
+			var split []int
+			total, split = convolve(total, tables[pos].best[f.starters(pos)], budget)
+			splits = append(splits, split)
+		}
+		cost := argmax(total)
+		if cost < 0 || total[cost] <= bestValue {
+			continue
+		}
+
+		r := &Result{Formation: f, Cost: cost}
+		spend := [5]int{}
+		c := cost
+		for pos := FWD; pos >= DEF; pos-- {
+			left := splits[pos-DEF][c]
+			spend[pos] = c - left
+			c = left
+		}
+		spend[GKP] = c
+		for pos := GKP; pos <= FWD; pos++ {
+			r.Picks = append(r.Picks, tables[pos].picks(f.starters(pos), spend[pos])...)
+		}
+		best, bestValue = r, total[cost]
+	}
+	if best == nil {
+		return nil, negInf
+	}
+	for _, p := range best.Picks {
+		if p.Starter {
+			best.XIScore += p.Score
+			best.Objective += p.Score
+		} else {
+			best.Objective += s.rules.BenchWeight * p.Score
+		}
+	}
+	sort.SliceStable(best.Picks, func(i, j int) bool {
+		a, b := best.Picks[i], best.Picks[j]
+		if a.Starter != b.Starter {
+			return a.Starter
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.Score > b.Score
+	})
+	return best, bestValue
+}
+
+// convolve returns out[c] = max over x of a[x] + b[c-x] for c <= budget,
+// along with the x achieving each maximum.
+func convolve(a, b []float64, budget int) ([]float64, []int) {
+	out := make([]float64, budget+1)
+	split := make([]int, budget+1)
+	for i := range out {
+		out[i] = negInf
+	}
+	var bs []int
+	for y, v := range b {
+		if v != negInf {
+			bs = append(bs, y)
+		}
+	}
+	for x, av := range a {
+		if av == negInf {
+			continue
+		}
+		for _, y := range bs {
+			if x+y > budget {
+				break
+			}
+			if v := av + b[y]; v > out[x+y] {
+				out[x+y] = v
+				split[x+y] = x
+			}
+		}
+	}
+	return out, split
+}
+
+func argmax(values []float64) int {
+	best := -1
+	for i, v := range values {
+		if v != negInf && (best < 0 || v > values[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// posTable holds one position's dynamic program: best[s][c] is the highest
+// value of a selection with s starters and size-s bench players costing
+// exactly c.
+type posTable struct {
+	pool   []Candidate
+	size   int
+	budget int
+	best   [][]float64
+	// choice[i] records, for each (starters, bench, cost) state after
+	// considering pool[i], whether pool[i] was skipped, started or benched.
+	choice [][]int8
+}
+
+const (
+	skip int8 = iota
+	start
+	bench
+)
+
+func solvePosition(pool []Candidate, forced []bool, size, budget int, benchWeight float64, penalty map[int]float64) *posTable {
+	t := &posTable{pool: pool, size: size, budget: budget}
+	dim := size + 1
+	idx := func(k, j, c int) int { return (k*dim+j)*(budget+1) + c }
+	states := dim * dim * (budget + 1)
+
+	cur := make([]float64, states)
+	for i := range cur {
+		cur[i] = negInf
+	}
+	cur[idx(0, 0, 0)] = 0
+	next := make([]float64, states)
+
+	for i, c := range pool {
+		startValue := c.Score - penalty[c.Team]
+		benchValue := benchWeight*c.Score - penalty[c.Team]
+		choice := make([]int8, states)
+		for k := 0; k <= size; k++ {
+			for j := 0; k+j <= size; j++ {
+				for cost := 0; cost <= budget; cost++ {
+					at := idx(k, j, cost)
+					v, how := negInf, skip
+					if !forced[i] {
+						v = cur[at]
+					}
+					if cost >= c.Cost {
+						if k > 0 {
+							if w := cur[idx(k-1, j, cost-c.Cost)]; w != negInf && w+startValue > v {
+								v, how = w+startValue, start
+							}
+						}
+						if j > 0 {
+							if w := cur[idx(k, j-1, cost-c.Cost)]; w != negInf && w+benchValue > v {
+								v, how = w+benchValue, bench
+							}
+						}
+					}
+					next[at], choice[at] = v, how
+				}
+			}
+		}
+		cur, next = next, cur
+		t.choice = append(t.choice, choice)
+	}
+
+	t.best = make([][]float64, dim)
+	for k := 0; k <= size; k++ {
+		t.best[k] = make([]float64, budget+1)
+		for cost := 0; cost <= budget; cost++ {
+			t.best[k][cost] = cur[idx(k, size-k, cost)]
+		}
+	}
+	return t
+}
+
+// picks walks the choices back from (starters, bench, cost).
+func (t *posTable) picks(starters, cost int) []Pick {
+	dim := t.size + 1
+	k, j := starters, t.size-starters
+	var out []Pick
+	for i := len(t.pool) - 1; i >= 0; i-- {
+		c := t.pool[i]
+		switch t.choice[i][(k*dim+j)*(t.budget+1)+cost] {
+		case start:
+			out = append(out, Pick{Candidate: c, Starter: true})
+			k--
+			cost -= c.Cost
+		case bench:
+			out = append(out, Pick{Candidate: c})
+			j--
+			cost -= c.Cost
+		}
+	}
+	return out
+}
+
+// nodeQueue is a max-heap on node bounds.
+type nodeQueue []*node
+
+func (q nodeQueue) Len() int           { return len(q) }
+func (q nodeQueue) Less(i, j int) bool { return q[i].bound > q[j].bound }
+func (q nodeQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x any)        { *q = append(*q, x.(*node)) }
+func (q *nodeQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
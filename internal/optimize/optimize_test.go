@@ -0,0 +1,214 @@
+package optimize
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// smallRules keeps instances small enough to brute force.
+func smallRules() Rules {
+	return Rules{
+		Budget:      200,
+		Squad:       [5]int{0, 1, 2, 2, 1},
+		MaxPerClub:  2,
+		Formations:  []Formation{{DEF: 1, MID: 2, FWD: 1}, {DEF: 2, MID: 1, FWD: 1}},
+		BenchWeight: 0.25,
+	}
+}
+
+func randomCandidates(rng *rand.Rand, n int) []Candidate {
+	cands := make([]Candidate, n)
+	for i := range cands {
+		cands[i] = Candidate{
+			ID:       i + 1,
+			Team:     1 + rng.Intn(3),
+			Position: GKP + i%4,
+			Cost:     20 + rng.Intn(40),
+			Score:    float64(rng.Intn(100)) / 10,
+		}
+	}
+	return cands
+}
+
+// bruteForce enumerates every subset and returns the best objective, or
+// -Inf when nothing is feasible.
+func bruteForce(cands []Candidate, rules Rules, locked map[int]bool) float64 {
+	best := math.Inf(-1)
+	size := rules.squadSize()
+	for mask := 0; mask < 1<<len(cands); mask++ {
+		var squad []Candidate
+		for i, c := range cands {
+			if mask&(1<<i) != 0 {
+				squad = append(squad, c)
+			} else if locked[c.ID] {
+				squad = nil
+				break
+			}
+		}
+		if len(squad) != size {
+			continue
+		}
+		cost, perPos, perClub, ok := 0, [5]int{}, map[int]int{}, true
+		for _, c := range squad {
+			cost += c.Cost
+			perPos[c.Position]++
+			perClub[c.Team]++
+			if perClub[c.Team] > rules.MaxPerClub {
+				ok = false
+			}
+		}
+		if !ok || cost > rules.Budget || perPos != rules.Squad {
+			continue
+		}
+		for _, f := range rules.Formations {
+			if v := bestLineup(squad, f, rules.BenchWeight); v > best {
+				best = v
+			}
+		}
+	}
+	return best
+}
+
+// bestLineup starts the top scorers in each position for formation f.
+func bestLineup(squad []Candidate, f Formation, benchWeight float64) float64 {
+	total := 0.0
+	for pos := GKP; pos <= FWD; pos++ {
+		var scores []float64
+		for _, c := range squad {
+			if c.Position == pos {
+				scores = append(scores, c.Score)
+			}
+		}
+		for i := 0; i < len(scores); i++ {
+			for j := i + 1; j < len(scores); j++ {
+				if scores[j] > scores[i] {
+					scores[i], scores[j] = scores[j], scores[i]
+				}
+			}
+		}
+		for i, s := range scores {
+			if i < f.starters(pos) {
+				total += s
+			} else {
+				total += benchWeight * s
+			}
+		}
+	}
+	return total
+}
+
+func TestSolveMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	rules := smallRules()
+	for trial := 0; trial < 60; trial++ {
+		cands := randomCandidates(rng, 14)
+		var locked []int
+		lockSet := map[int]bool{}
+		if trial%3 == 0 {
+			id := cands[rng.Intn(len(cands))].ID
+			locked = append(locked, id)
+			lockSet[id] = true
+		}
+
+		want := bruteForce(cands, rules, lockSet)
+		got, err := Solve(cands, rules, locked, nil)
+		if math.IsInf(want, -1) {
+			if !errors.Is(err, ErrInfeasible) {
+				t.Fatalf("trial %d: expected infeasible, got %+v (%v)", trial, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("trial %d: unexpected error: %v", trial, err)
+		}
+		if math.Abs(got.Objective-want) > 1e-9 {
+			t.Fatalf("trial %d: objective %.4f, brute force %.4f", trial, got.Objective, want)
+		}
+		checkSquad(t, got, rules, lockSet)
+	}
+}
+
+func checkSquad(t *testing.T, r *Result, rules Rules, locked map[int]bool) {
+	t.Helper()
+	cost, perPos, perClub, starters := 0, [5]int{}, map[int]int{}, [5]int{}
+	seen := map[int]bool{}
+	for _, p := range r.Picks {
+		cost += p.Cost
+		perPos[p.Position]++
+		perClub[p.Team]++
+		if p.Starter {
+			starters[p.Position]++
+		}
+		seen[p.ID] = true
+	}
+	if cost != r.Cost || cost > rules.Budget {
+		t.Fatalf("cost %d (reported %d) exceeds budget %d", cost, r.Cost, rules.Budget)
+	}
+	if perPos != rules.Squad {
+		t.Fatalf("squad shape %v, want %v", perPos, rules.Squad)
+	}
+	for team, n := range perClub {
+		if n > rules.MaxPerClub {
+			t.Fatalf("%d players from team %d", n, team)
+		}
+	}
+	f := r.Formation
+	if starters != [5]int{0, 1, f.DEF, f.MID, f.FWD} {
+		t.Fatalf("starters %v do not match formation %s", starters, f)
+	}
+	for id := range locked {
+		if !seen[id] {
+			t.Fatalf("locked player %d missing", id)
+		}
+	}
+}
+
+func TestSolveDefaultRules(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	var cands []Candidate
+	for i := 0; i < 600; i++ {
+		cands = append(cands, Candidate{
+			ID:       i + 1,
+			Team:     1 + i%20,
+			Position: []int{GKP, DEF, DEF, MID, MID, MID, FWD}[i%7],
+			Cost:     40 + rng.Intn(90),
+			Score:    float64(rng.Intn(80)) / 10,
+		})
+	}
+	// Make one club irresistible so the club limit has to bind.
+	for i := range cands {
+		if cands[i].Team == 1 {
+			cands[i].Score += 20
+			cands[i].Cost = 45
+		}
+	}
+
+	rules := DefaultRules()
+	locked := []int{cands[1].ID}
+	excluded := []int{cands[0].ID}
+	r, err := Solve(cands, rules, locked, excluded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSquad(t, r, rules, map[int]bool{cands[1].ID: true})
+	for _, p := range r.Picks {
+		if p.ID == cands[0].ID {
+			t.Fatal("excluded player was picked")
+		}
+	}
+	if r.Nodes < 2 {
+		t.Fatalf("expected the club limit to need more than one relaxation, got %d", r.Nodes)
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	cands := []Candidate{{ID: 1, Team: 1, Position: GKP, Cost: 40}}
+	if _, err := Solve(cands, DefaultRules(), []int{1}, []int{1}); err == nil {
+		t.Fatal("expected lock/exclude conflict")
+	}
+	if _, err := Solve(cands, DefaultRules(), nil, nil); !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("expected ErrInfeasible, got %v", err)
+	}
+}
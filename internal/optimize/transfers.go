@@ -0,0 +1,333 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Squad is a manager's current team. Each player's Cost is their selling
+// price rather than their market price.
+type Squad struct {
+	Players       []Candidate
+	Bank          int
+	FreeTransfers int
+}
+
+// TransferOptions bounds the transfer search.
+type TransferOptions struct {
+	// MaxTransfers is 1 for single transfers only, or 2 to add pairs.
+	MaxTransfers int
+	// HitCost is the points deducted for each transfer beyond the free ones.
+	HitCost float64
+	// Limit is how many moves to return.
+	Limit int
+}
+
+// Move is a set of players sold and bought together. Out[i] is replaced by
+// In[i], which plays the same position.
+type Move struct {
+	Out, In []Candidate
+	// Hits is the number of transfers beyond the free ones.
+	Hits int
+	// Bank is left over after the move, in tenths of £1m.
+	Bank int
+	// Score is the squad's projected points over the horizon before hits;
+	// Gain is Score less the current squad's, and Net deducts the hits.
+	Score, Gain, Net float64
+}
+
+// PlanTransfers returns the best moves by net gain, with the current squad's
+// projected score. scores holds each player's points per gameweek; a squad is
+// valued by its best XI in each gameweek separately, so blanking players are
+// benched. Every single transfer is tried and, when MaxTransfers is 2, every
+// pair. Pool players who are beaten on price and every gameweek's score by
+// enough others to always leave a legal swap are skipped, which cannot
+// change the best moves.
+func PlanTransfers(squad Squad, pool []Candidate, scores map[int][]float64, rules Rules, opts TransferOptions) ([]Move, float64, error) {
+	if opts.MaxTransfers < 1 || opts.MaxTransfers > 2 {
+		return nil, 0, fmt.Errorf("max transfers must be 1 or 2, got %d", opts.MaxTransfers)
+	}
+	if opts.Limit < 1 {
+		return nil, 0, fmt.Errorf("limit must be at least 1, got %d", opts.Limit)
+	}
+	if len(squad.Players) != rules.squadSize() {
+		return nil, 0, fmt.Errorf("squad has %d players, want %d", len(squad.Players), rules.squadSize())
+	}
+	if len(rules.Formations) == 0 {
+		return nil, 0, errors.New("at least one formation is required")
+	}
+	p := newPlanner(squad, pool, scores, rules, opts)
+	base := p.value(squad.Players)
+	p.singles(base)
+	if opts.MaxTransfers == 2 {
+		p.pairs(base)
+	}
+	return p.best, base, nil
+}
+
+type planner struct {
+	squad  Squad
+	rules  Rules
+	opts   TransferOptions
+	scores map[int][]float64
+	weeks  int
+	// pool holds the buyable players by position.
+	pool  [5][]Candidate
+	clubs map[int]int
+	best  []Move
+	// singleNet records each single transfer's net gain, keyed by out and in
+	// IDs, so that pairs adding nothing to one of their halves are dropped.
+	singleNet map[[2]int]float64
+	lineup    []Candidate
+}
+
+func newPlanner(squad Squad, pool []Candidate, scores map[int][]float64, rules Rules, opts TransferOptions) *planner {
+	p := &planner{
+		squad:     squad,
+		rules:     rules,
+		opts:      opts,
+		scores:    scores,
+		clubs:     make(map[int]int),
+		singleNet: make(map[[2]int]float64),
+		lineup:    make([]Candidate, len(squad.Players)),
+	}
+	for _, weeks := range scores {
+		p.weeks = max(p.weeks, len(weeks))
+	}
+	owned := make(map[int]bool, len(squad.Players))
+	for _, c := range squad.Players {
+		owned[c.ID] = true
+		p.clubs[c.Team]++
+	}
+	var byPos [5][]Candidate
+	for _, c := range pool {
+		if !owned[c.ID] && c.Position >= GKP && c.Position <= FWD {
+			byPos[c.Position] = append(byPos[c.Position], c)
+		}
+	}
+	// A dominated player can be swapped for one of their dominators unless
+	// that dominator is the other player bought or their club is full.
+	need := opts.MaxTransfers + rules.squadSize()/rules.MaxPerClub
+	for pos := GKP; pos <= FWD; pos++ {
+		p.pool[pos] = p.undominated(byPos[pos], need)
+	}
+	return p
+}
+
+func (p *planner) week(id, w int) float64 {
+	if weeks := p.scores[id]; w < len(weeks) {
+		return weeks[w]
+	}
+	return 0
+}
+
+// dominates reports whether q is at least as cheap as c and scores at least
+// as much every gameweek, breaking exact ties by ID.
+func (p *planner) dominates(q, c Candidate) bool {
+	if q.Cost > c.Cost {
+		return false
+	}
+	strict := q.Cost < c.Cost
+	for w := 0; w < p.weeks; w++ {
+		qs, cs := p.week(q.ID, w), p.week(c.ID, w)
+		if qs < cs {
+			return false
+		}
+		strict = strict || qs > cs
+	}
+	return strict || q.ID < c.ID
+}
+
+func (p *planner) undominated(cands []Candidate, need int) []Candidate {
+	var kept []Candidate
+	for _, c := range cands {
+		clubs := make(map[int]bool)
+		for _, q := range cands {
+			if q.ID != c.ID && !clubs[q.Team] && p.dominates(q, c) {
+				clubs[q.Team] = true
+				if len(clubs) >= need {
+					break
+				}
+			}
+		}
+		if len(clubs) < need {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// value is the squad's projected points: the best XI's score plus the
+// weighted bench in each gameweek.
+func (p *planner) value(squad []Candidate) float64 {
+	total := 0.0
+	var byPos [5][]float64
+	for w := 0; w < p.weeks; w++ {
+		for pos := range byPos {
+			byPos[pos] = byPos[pos][:0]
+		}
+		for _, c := range squad {
+			byPos[c.Position] = append(byPos[c.Position], p.week(c.ID, w))
+		}
+		total += lineupValue(byPos, p.rules)
+	}
+	return total
+}
+
+// lineupValue picks the formation whose starters, plus the weighted bench,
+// score most given each position's scores.
+func lineupValue(byPos [5][]float64, rules Rules) float64 {
+	var all [5]float64
+	for pos := GKP; pos <= FWD; pos++ {
+		sort.Sort(sort.Reverse(sort.Float64Slice(byPos[pos])))
+		for _, s := range byPos[pos] {
+			all[pos] += s
+		}
+	}
+	best := negInf
+	for _, f := range rules.Formations {
+		v := 0.0
+		for pos := GKP; pos <= FWD; pos++ {
+			starters := 0.0
+			for _, s := range byPos[pos][:min(f.starters(pos), len(byPos[pos]))] {
+				starters += s
+			}
+			v += starters + rules.BenchWeight*(all[pos]-starters)
+		}
+		best = max(best, v)
+	}
+	return best
+}
+
+func (p *planner) hits(n int) int {
+	return max(0, n-p.squad.FreeTransfers)
+}
+
+// fits reports whether the club limit holds after selling outs and buying
+// ins.
+func (p *planner) fits(outs, ins []Candidate) bool {
+	for _, in := range ins {
+		n := p.clubs[in.Team]
+		for _, o := range outs {
+			if o.Team == in.Team {
+				n--
+			}
+		}
+		for _, other := range ins {
+			if other.Team == in.Team {
+				n++
+			}
+		}
+		if n > p.rules.MaxPerClub {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate scores the squad with outs[i] replaced by ins[i].
+func (p *planner) evaluate(outs, ins []Candidate, base float64) Move {
+	copy(p.lineup, p.squad.Players)
+	bank := p.squad.Bank
+	for i := range outs {
+		for j := range p.lineup {
+			if p.lineup[j].ID == outs[i].ID {
+				p.lineup[j] = ins[i]
+			}
+		}
+		bank += outs[i].Cost - ins[i].Cost
+	}
+	score := p.value(p.lineup)
+	hits := p.hits(len(outs))
+	return Move{
+		Out:   append([]Candidate(nil), outs...),
+		In:    append([]Candidate(nil), ins...),
+		Hits:  hits,
+		Bank:  bank,
+		Score: score,
+		Gain:  score - base,
+		Net:   score - base - float64(hits)*p.opts.HitCost,
+	}
+}
+
+func (p *planner) singles(base float64) {
+	for _, out := range p.squad.Players {
+		for _, in := range p.pool[out.Position] {
+			outs, ins := []Candidate{out}, []Candidate{in}
+			if p.squad.Bank+out.Cost < in.Cost || !p.fits(outs, ins) {
+				continue
+			}
+			m := p.evaluate(outs, ins, base)
+			p.singleNet[[2]int{out.ID, in.ID}] = m.Net
+			p.offer(m)
+		}
+	}
+}
+
+func (p *planner) pairs(base float64) {
+	players := p.squad.Players
+	for i := range players {
+		for j := i + 1; j < len(players); j++ {
+			o1, o2 := players[i], players[j]
+			funds := p.squad.Bank + o1.Cost + o2.Cost
+			pool1, pool2 := p.pool[o1.Position], p.pool[o2.Position]
+			for a, c1 := range pool1 {
+				start := 0
+				if o1.Position == o2.Position {
+					// Unordered pairs of buys suffice within one position.
+					start = a + 1
+				}
+				for _, c2 := range pool2[start:] {
+					outs, ins := []Candidate{o1, o2}, []Candidate{c1, c2}
+					if c1.Cost+c2.Cost > funds || !p.fits(outs, ins) {
+						continue
+					}
+					m := p.evaluate(outs, ins, base)
+					if m.Net <= p.bestHalf(outs, ins) {
+						continue
+					}
+					p.offer(m)
+				}
+			}
+		}
+	}
+}
+
+// bestHalf is the best net gain of a single transfer contained in the pair,
+// or -Inf when none of them is legal on its own.
+func (p *planner) bestHalf(outs, ins []Candidate) float64 {
+	best := negInf
+	for _, o := range outs {
+		for _, c := range ins {
+			if net, ok := p.singleNet[[2]int{o.ID, c.ID}]; ok {
+				best = max(best, net)
+			}
+		}
+	}
+	return best
+}
+
+// offer keeps m if it is among the best Limit moves seen, ranked by net gain,
+// then fewer transfers, then more money left.
+func (p *planner) offer(m Move) {
+	better := func(a, b Move) bool {
+		if a.Net != b.Net {
+			return a.Net > b.Net
+		}
+		if len(a.Out) != len(b.Out) {
+			return len(a.Out) < len(b.Out)
+		}
+		return a.Bank > b.Bank
+	}
+	if len(p.best) >= p.opts.Limit && !better(m, p.best[len(p.best)-1]) {
+		return
+	}
+	i := sort.Search(len(p.best), func(i int) bool { return better(m, p.best[i]) })
+	p.best = append(p.best, Move{})
+	copy(p.best[i+1:], p.best[i:])
+	p.best[i] = m
+	if len(p.best) > p.opts.Limit {
+		p.best = p.best[:p.opts.Limit]
+	}
+}
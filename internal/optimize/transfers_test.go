@@ -0,0 +1,127 @@
+package optimize
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteTransfers tries every single and pair of transfers without pruning
+// and returns the best net gain.
+func bruteTransfers(squad Squad, pool []Candidate, scores map[int][]float64, rules Rules, hitCost float64) float64 {
+	p := newPlanner(squad, nil, scores, rules, TransferOptions{MaxTransfers: 2, HitCost: hitCost, Limit: 1})
+	base := p.value(squad.Players)
+	best := negInf
+	try := func(outs, ins []Candidate) {
+		spend := 0
+		for i := range outs {
+			if outs[i].Position != ins[i].Position {
+				return
+			}
+			spend += ins[i].Cost - outs[i].Cost
+		}
+		if spend > squad.Bank || !p.fits(outs, ins) {
+			return
+		}
+		best = math.Max(best, p.evaluate(outs, ins, base).Net)
+	}
+	players := squad.Players
+	for i := range players {
+		for a := range pool {
+			try([]Candidate{players[i]}, []Candidate{pool[a]})
+			for j := i + 1; j < len(players); j++ {
+				for b := range pool {
+					if b != a {
+						try([]Candidate{players[i], players[j]}, []Candidate{pool[a], pool[b]})
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+func TestPlanTransfersMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	rules := smallRules()
+	for trial := 0; trial < 40; trial++ {
+		var squad Squad
+		for pos, n := range rules.Squad {
+			for k := 0; k < n; k++ {
+				squad.Players = append(squad.Players, Candidate{ID: 100 + len(squad.Players), Team: 1 + rng.Intn(3), Position: pos, Cost: 20 + rng.Intn(40)})
+			}
+		}
+		squad.Bank = rng.Intn(20)
+		squad.FreeTransfers = trial % 3
+		pool := randomCandidates(rng, 16)
+
+		scores := map[int][]float64{}
+		for _, c := range append(append([]Candidate(nil), squad.Players...), pool...) {
+			scores[c.ID] = []float64{float64(rng.Intn(60)) / 10, float64(rng.Intn(60)) / 10}
+		}
+		// Keep club counts legal in the starting squad.
+		clubs := map[int]int{}
+		for i := range squad.Players {
+			for clubs[squad.Players[i].Team] >= rules.MaxPerClub {
+				squad.Players[i].Team++
+			}
+			clubs[squad.Players[i].Team]++
+		}
+
+		want := bruteTransfers(squad, pool, scores, rules, 4)
+		moves, _, err := PlanTransfers(squad, pool, scores, rules, TransferOptions{MaxTransfers: 2, HitCost: 4, Limit: 5})
+		if err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+		if math.IsInf(want, -1) {
+			if len(moves) != 0 {
+				t.Fatalf("trial %d: expected no legal moves, got %+v", trial, moves[0])
+			}
+			continue
+		}
+		if len(moves) == 0 || math.Abs(moves[0].Net-want) > 1e-9 {
+			t.Fatalf("trial %d: best net %v, brute force %.4f", trial, moves, want)
+		}
+		for i := 1; i < len(moves); i++ {
+			if moves[i].Net > moves[i-1].Net {
+				t.Fatalf("trial %d: moves out of order", trial)
+			}
+		}
+	}
+}
+
+func TestPlanTransfersHits(t *testing.T) {
+	rules := smallRules()
+	squad := Squad{Bank: 0, FreeTransfers: 1}
+	scores := map[int][]float64{}
+	for pos, n := range rules.Squad {
+		for k := 0; k < n; k++ {
+			id := len(squad.Players) + 1
+			squad.Players = append(squad.Players, Candidate{ID: id, Team: id, Position: pos, Cost: 50})
+			scores[id] = []float64{1}
+		}
+	}
+	pool := []Candidate{
+		{ID: 50, Team: 50, Position: MID, Cost: 50},
+		{ID: 51, Team: 51, Position: FWD, Cost: 50},
+	}
+	scores[5] = []float64{2}
+	scores[50] = []float64{4}
+	scores[51] = []float64{3.5}
+
+	moves, base, err := PlanTransfers(squad, pool, scores, rules, TransferOptions{MaxTransfers: 2, HitCost: 4, Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base != 6+0.25 {
+		t.Fatalf("unexpected base score %.2f", base)
+	}
+	// Both singles are free; the pair pays a hit that its second half does
+	// not earn back, so it is dropped.
+	if len(moves) != 3 || moves[0].Out[0].ID != 4 || moves[0].Hits != 0 || moves[1].In[0].ID != 51 || moves[2].Out[0].ID != 5 {
+		t.Fatalf("unexpected moves %+v", moves)
+	}
+	if _, _, err := PlanTransfers(squad, pool, scores, rules, TransferOptions{MaxTransfers: 3, Limit: 1}); err == nil {
+		t.Fatal("expected max transfers above 2 to be rejected")
+	}
+}
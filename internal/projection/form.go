@@ -0,0 +1,131 @@
+package projection
+
+import (
+	"sort"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+const (
+	// FormWindow is how many of a player's latest matches Form averages.
+	FormWindow = 5
+	// difficultyStep scales form by 10% per step of fixture difficulty
+	// either side of an average (3) fixture.
+	difficultyStep = 0.1
+	// venueStep favours home fixtures over away ones.
+	venueStep = 0.05
+)
+
+// Form projects a player's average points over their latest matches,
+// including any they missed, for each upcoming fixture, adjusted for its
+// difficulty and venue and scaled by availability.
+type Form struct {
+	schedule *Schedule
+	next     int
+	elements map[int]*fpl.Element
+	averages map[int]float64
+}
+
+// NewForm builds a Form from each player's match history, keyed by element
+// ID. Players without history project nothing.
+func NewForm(b *fpl.BootstrapStatic, s *Schedule, next int, histories map[int][]fpl.HistoryEntry) *Form {
+	f := &Form{
+		schedule: s,
+		next:     next,
+		elements: indexElements(b),
+		averages: make(map[int]float64, len(histories)),
+	}
+	for id, history := range histories {
+		f.averages[id] = RecentAverage(history, FormWindow)
+	}
+	return f
+}
+
+// RecentAverage is the mean points over a player's last n matches. History
+// has a row for every team fixture since the player joined, so matches they
+// missed count as zero.
+func RecentAverage(history []fpl.HistoryEntry, n int) float64 {
+	rows := append([]fpl.HistoryEntry(nil), history...)
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Round < rows[j].Round })
+	if len(rows) > n {
+		rows = rows[len(rows)-n:]
+	}
+	if len(rows) == 0 {
+		return 0
+	}
+	total := 0
+	for _, h := range rows {
+		total += h.TotalPoints
+	}
+	return float64(total) / float64(len(rows))
+}
+
+// Project implements Projection.
+func (f *Form) Project(playerID, gw int) Estimate {
+	est := Estimate{PlayerID: playerID, Gameweek: gw}
+	el := f.elements[playerID]
+	if el == nil {
+		return est
+	}
+	avail := Availability(el, gw, f.next)
+	for _, m := range f.schedule.Matches(el.Team, gw) {
+		est.Fixtures++
+		est.Points += f.averages[playerID] * avail * MatchFactor(m)
+	}
+	return est
+}
+
+// MatchFactor is how much easier than average a fixture is: difficulty 2 at
+// home is 1.15, difficulty 5 away 0.75. Unrated fixtures count as average.
+func MatchFactor(m Match) float64 {
+	factor := 1.0
+	if m.Difficulty > 0 {
+		factor += float64(3-m.Difficulty) * difficultyStep
+	}
+	if m.Home {
+		return factor + venueStep
+	}
+	return factor - venueStep
+}
+
+// Weighted is one part of a Blend.
+type Weighted struct {
+	Projection Projection
+	Weight     float64
+}
+
+// Blend averages projections by weight, component by component.
+type Blend []Weighted
+
+// Project implements Projection.
+func (b Blend) Project(playerID, gw int) Estimate {
+	est := Estimate{PlayerID: playerID, Gameweek: gw}
+	total := 0.0
+	for _, part := range b {
+		total += part.Weight
+	}
+	if total == 0 {
+		return est
+	}
+	for _, part := range b {
+		e := part.Projection.Project(playerID, gw)
+		est.Fixtures = max(est.Fixtures, e.Fixtures)
+		e.Fixtures = 0
+		est.Add(e.scale(part.Weight / total))
+	}
+	return est
+}
+
+func (e Estimate) scale(f float64) Estimate {
+	e.Minutes *= f
+	e.Appearance *= f
+	e.Goals *= f
+	e.Assists *= f
+	e.CleanSheets *= f
+	e.Conceded *= f
+	e.Saves *= f
+	e.Bonus *= f
+	e.Cards *= f
+	e.Points *= f
+	return e
+}
@@ -0,0 +1,71 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func TestRecentAverage(t *testing.T) {
+	history := []fpl.HistoryEntry{
+		{Round: 6, TotalPoints: 0},
+		{Round: 1, TotalPoints: 20},
+		{Round: 2, TotalPoints: 2},
+		{Round: 3, TotalPoints: 8},
+		{Round: 4, TotalPoints: 6},
+		{Round: 4, TotalPoints: 4},
+	}
+	// The GW1 haul falls outside the window; the blank GW6 counts as zero.
+	if got := RecentAverage(history, 5); got != 4 {
+		t.Fatalf("expected 4, got %.2f", got)
+	}
+	if got := RecentAverage(nil, 5); got != 0 {
+		t.Fatalf("expected 0 without history, got %.2f", got)
+	}
+}
+
+func TestForm(t *testing.T) {
+	b, fixtures := testData()
+	s := NewSchedule(fixtures)
+	f := NewForm(b, s, 5, map[int][]fpl.HistoryEntry{
+		10: {{Round: 1, TotalPoints: 6}, {Round: 2, TotalPoints: 4}},
+		13: {{Round: 1, TotalPoints: 6}},
+	})
+	easy := Match{Home: true, Difficulty: 2}
+	if got := MatchFactor(easy); math.Abs(got-1.15) > 1e-9 {
+		t.Fatalf("expected 1.15 for an easy home game, got %.2f", got)
+	}
+	if got := MatchFactor(Match{Difficulty: 5}); math.Abs(got-0.75) > 1e-9 {
+		t.Fatalf("expected 0.75 for a hard away game, got %.2f", got)
+	}
+
+	if got := f.Project(10, 5); got.Fixtures != 1 || math.Abs(got.Points-5.25) > 1e-9 {
+		t.Fatalf("expected 5.25 points at home, got %+v", got)
+	}
+	if got := f.Project(10, 6); got.Fixtures != 2 || math.Abs(got.Points-10) > 1e-9 {
+		t.Fatalf("expected two fixtures worth 10 points, got %+v", got)
+	}
+	if got := f.Project(13, 5).Points; got != 0 {
+		t.Fatalf("expected nothing from an injured keeper, got %.2f", got)
+	}
+	if got := f.Project(11, 5).Points; got != 0 {
+		t.Fatalf("expected nothing without history, got %.2f", got)
+	}
+}
+
+func TestBlend(t *testing.T) {
+	b, fixtures := testData()
+	s := NewSchedule(fixtures)
+	model := NewModel(b, s, 5)
+	form := NewForm(b, s, 5, map[int][]fpl.HistoryEntry{10: {{Round: 1, TotalPoints: 8}}})
+	blend := Blend{{model, 3}, {form, 1}}
+
+	m, f, got := model.Project(10, 6), form.Project(10, 6), blend.Project(10, 6)
+	if want := 0.75*m.Points + 0.25*f.Points; math.Abs(got.Points-want) > 1e-9 {
+		t.Fatalf("expected %.3f, got %.3f", want, got.Points)
+	}
+	if math.Abs(got.Goals-0.75*m.Goals) > 1e-9 || got.Fixtures != 2 {
+		t.Fatalf("expected components weighted by share, got %+v", got)
+	}
+}
@@ -0,0 +1,236 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+// Element type IDs as used by the FPL API.
+const (
+	gkp = 1
+	def = 2
+	mid = 3
+	fwd = 4
+)
+
+// Scoring, indexed by element type where it differs by position.
+var (
+	goalPoints       = [5]float64{0, 10, 6, 5, 4}
+	cleanSheetPoints = [5]float64{0, 4, 4, 1, 0}
+)
+
+const (
+	assistPoints  = 3
+	savesPerPoint = 3
+	redCardPoints = 3
+)
+
+const (
+	// priorMinutes of position-average output are blended into each
+	// player's per-90 rates, so a few lucky cameos do not dominate.
+	priorMinutes = 450
+	// startMinutes and subMinutes are typical minutes for a start and a
+	// substitute appearance, used to split season minutes into appearances.
+	startMinutes = 80
+	subMinutes   = 20
+	// completeShare is the share of starts that last at least 60 minutes.
+	completeShare = 0.9
+	// sixtyPoints is what a 60-minute appearance earns. Before a team's
+	// first finished fixture, a player whose ep_next covers it is taken as
+	// a regular starter.
+	sixtyPoints = 2
+)
+
+// defaultPriors stand in for a position's average per-90 output before
+// anyone in it has played, much like defaultGoalsPerTeam.
+var defaultPriors = [5]per90{
+	gkp: {xa: 0.01, saves: 3, bonus: 0.2, yellow: 0.05},
+	def: {xg: 0.05, xa: 0.07, bonus: 0.25, yellow: 0.15, red: 0.005},
+	mid: {xg: 0.2, xa: 0.15, bonus: 0.3, yellow: 0.15, red: 0.005},
+	fwd: {xg: 0.4, xa: 0.12, bonus: 0.4, yellow: 0.12, red: 0.005},
+}
+
+// per90 holds per-90-minute rates.
+type per90 struct {
+	xg, xa, saves, bonus, yellow, red float64
+}
+
+// Model projects points fixture by fixture from each player's season:
+// minutes decide how likely they are to play and for how long, per-90
+// expected goals, expected assists, saves, bonus and cards are blended with
+// their position's average, and attacking returns and clean sheet odds are
+// scaled by the venue-specific strength of both teams. Goals conceded are
+// modelled as Poisson.
+type Model struct {
+	schedule *Schedule
+	next     int
+	elements map[int]*fpl.Element
+	teams    map[int]*fpl.Team
+	priors   [5]per90
+	// goals is the league's goals per team per fixture; attack and defence
+	// are the mean strength ratings used to normalize each team's.
+	goals, attack, defence float64
+}
+
+// NewModel builds a Model from bootstrap data and the fixture schedule.
+// next is the next gameweek, the only one affected by injury flags.
+func NewModel(b *fpl.BootstrapStatic, s *Schedule, next int) *Model {
+	m := &Model{
+		schedule: s,
+		next:     next,
+		elements: indexElements(b),
+		teams:    make(map[int]*fpl.Team, len(b.Teams)),
+		goals:    s.GoalsPerTeam(),
+	}
+	for i := range b.Teams {
+		t := &b.Teams[i]
+		m.teams[t.ID] = t
+		m.attack += float64(t.StrengthAttackHome+t.StrengthAttackAway) / 2
+		m.defence += float64(t.StrengthDefenceHome+t.StrengthDefenceAway) / 2
+	}
+	if n := float64(len(b.Teams)); n > 0 {
+		m.attack /= n
+		m.defence /= n
+	}
+
+	var totals [5]per90
+	var minutes [5]float64
+	for _, el := range b.Elements {
+		if el.ElementType < gkp || el.ElementType > fwd || el.Minutes == 0 {
+			continue
+		}
+		minutes[el.ElementType] += float64(el.Minutes)
+		t := &totals[el.ElementType]
+		t.xg += stat(el.ExpectedGoals)
+		t.xa += stat(el.ExpectedAssists)
+		t.saves += float64(el.Saves)
+		t.bonus += float64(el.Bonus)
+		t.yellow += float64(el.YellowCards)
+		t.red += float64(el.RedCards)
+	}
+	for pos := range totals {
+		m.priors[pos] = defaultPriors[pos]
+		if minutes[pos] > 0 {
+			m.priors[pos] = totals[pos].scale(90 / minutes[pos])
+		}
+	}
+	return m
+}
+
+func (r per90) scale(f float64) per90 {
+	return per90{r.xg * f, r.xa * f, r.saves * f, r.bonus * f, r.yellow * f, r.red * f}
+}
+
+// Project implements Projection.
+func (m *Model) Project(playerID, gw int) Estimate {
+	est := Estimate{PlayerID: playerID, Gameweek: gw}
+	el := m.elements[playerID]
+	if el == nil {
+		return est
+	}
+	for _, match := range m.schedule.Matches(el.Team, gw) {
+		est.Add(m.fixture(el, match))
+	}
+	return est
+}
+
+// rates blends the player's season rates with priorMinutes of their
+// position's average.
+func (m *Model) rates(el *fpl.Element) per90 {
+	if el.ElementType < gkp || el.ElementType > fwd {
+		return per90{}
+	}
+	prior := m.priors[el.ElementType].scale(priorMinutes / 90)
+	f := 90 / float64(el.Minutes+priorMinutes)
+	return per90{
+		xg:     (stat(el.ExpectedGoals) + prior.xg) * f,
+		xa:     (stat(el.ExpectedAssists) + prior.xa) * f,
+		saves:  (float64(el.Saves) + prior.saves) * f,
+		bonus:  (float64(el.Bonus) + prior.bonus) * f,
+		yellow: (float64(el.YellowCards) + prior.yellow) * f,
+		red:    (float64(el.RedCards) + prior.red) * f,
+	}
+}
+
+// minutes returns the expected minutes in one fixture and the chances of
+// appearing and of playing 60 minutes, from how often the player has started
+// and come on in their team's finished fixtures. Until the team has finished
+// one, the API's ep_next stands in for that history.
+func (m *Model) minutes(el *fpl.Element, gw int) (expected, play, sixty float64) {
+	avail := Availability(el, gw, m.next)
+	games := float64(m.schedule.Played(el.Team))
+	if games == 0 {
+		startRate := avail * math.Min(1, stat(el.EPNext)/sixtyPoints)
+		return startRate * startMinutes, startRate, startRate * completeShare
+	}
+	starts := float64(el.Starts)
+	startRate := math.Min(1, starts/games)
+	subApps := math.Max(0, float64(el.Minutes)-starts*startMinutes) / subMinutes
+	subRate := math.Min(1-startRate, subApps/games)
+	expected = avail * math.Min(90, float64(el.Minutes)/games)
+	return expected, avail * (startRate + subRate), avail * startRate * completeShare
+}
+
+// strengths returns how much above average the player's team attacks in
+// this fixture and how many goals it expects to concede.
+func (m *Model) strengths(el *fpl.Element, match Match) (attack, conceded float64) {
+	team, opp := m.teams[el.Team], m.teams[match.Opponent]
+	if team == nil || opp == nil || m.attack == 0 || m.defence == 0 {
+		return 1, m.goals
+	}
+	att, dfn := team.StrengthAttackAway, team.StrengthDefenceAway
+	oppAtt, oppDef := opp.StrengthAttackHome, opp.StrengthDefenceHome
+	if match.Home {
+		att, dfn = team.StrengthAttackHome, team.StrengthDefenceHome
+		oppAtt, oppDef = opp.StrengthAttackAway, opp.StrengthDefenceAway
+	}
+	if att == 0 || dfn == 0 || oppAtt == 0 || oppDef == 0 {
+		return 1, m.goals
+	}
+	attack = (float64(att) / m.attack) / (float64(oppDef) / m.defence)
+	conceded = m.goals * (float64(oppAtt) / m.attack) / (float64(dfn) / m.defence)
+	return attack, conceded
+}
+
+func (m *Model) fixture(el *fpl.Element, match Match) Estimate {
+	pos := el.ElementType
+	est := Estimate{PlayerID: el.ID, Gameweek: match.Gameweek, Fixtures: 1}
+	if pos < gkp || pos > fwd {
+		return est
+	}
+	expected, play, sixty := m.minutes(el, match.Gameweek)
+	attack, conceded := m.strengths(el, match)
+	r := m.rates(el)
+	share := expected / 90
+
+	est.Minutes = expected
+	est.Appearance = play + sixty
+	est.Goals = r.xg * share * attack * goalPoints[pos]
+	est.Assists = r.xa * share * attack * assistPoints
+	est.CleanSheets = sixty * math.Exp(-conceded) * cleanSheetPoints[pos]
+	if pos == gkp || pos == def {
+		est.Conceded = -sixty * pairsConceded(conceded)
+	}
+	if pos == gkp && m.goals > 0 {
+		// Stronger opponents mean more shots to save.
+		est.Saves = r.saves * share * (conceded / m.goals) / savesPerPoint
+	}
+	est.Bonus = r.bonus * share
+	est.Cards = -(r.yellow + redCardPoints*r.red) * share
+	est.Points = est.Appearance + est.Goals + est.Assists + est.CleanSheets +
+		est.Conceded + est.Saves + est.Bonus + est.Cards
+	return est
+}
+
+// pairsConceded is the expected number of complete pairs of goals conceded,
+// each costing a point, when goals follow a Poisson distribution.
+func pairsConceded(lambda float64) float64 {
+	p := math.Exp(-lambda)
+	total := 0.0
+	for k := 1; k <= 20; k++ {
+		p *= lambda / float64(k)
+		total += p * float64(k/2)
+	}
+	return total
+}
@@ -0,0 +1,148 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+func intPtr(v int) *int { return &v }
+
+func testData() (*fpl.BootstrapStatic, []fpl.Fixture) {
+	b := &fpl.BootstrapStatic{
+		Teams: []fpl.Team{
+			{ID: 1, ShortName: "STR", StrengthAttackHome: 1300, StrengthAttackAway: 1300, StrengthDefenceHome: 1300, StrengthDefenceAway: 1300},
+			{ID: 2, ShortName: "WEK", StrengthAttackHome: 1000, StrengthAttackAway: 1000, StrengthDefenceHome: 1000, StrengthDefenceAway: 1000},
+			{ID: 3, ShortName: "MID", StrengthAttackHome: 1150, StrengthAttackAway: 1150, StrengthDefenceHome: 1150, StrengthDefenceAway: 1150},
+		},
+		Elements: []fpl.Element{
+			{ID: 10, Team: 3, ElementType: 4, Minutes: 360, Starts: 4, ExpectedGoals: "2.0", ExpectedAssists: "0.8", Bonus: 4, Status: "a"},
+			{ID: 11, Team: 3, ElementType: 2, Minutes: 360, Starts: 4, ExpectedGoals: "0.2", ExpectedAssists: "0.2", Bonus: 1, Status: "a"},
+			{ID: 12, Team: 3, ElementType: 4, Minutes: 60, Starts: 0, ExpectedGoals: "0.1", Status: "a"},
+			{ID: 13, Team: 3, ElementType: 1, Minutes: 360, Starts: 4, Saves: 12, Status: "i", ChanceOfPlayingNextRound: intPtr(0)},
+		},
+	}
+	finished := func(id, h, a, hs, as int) fpl.Fixture {
+		return fpl.Fixture{ID: id, Event: intPtr(id), TeamH: h, TeamA: a, TeamHScore: intPtr(hs), TeamAScore: intPtr(as), Finished: true}
+	}
+	fixtures := []fpl.Fixture{
+		finished(1, 3, 1, 1, 2),
+		finished(2, 2, 3, 0, 0),
+		finished(3, 3, 1, 2, 2),
+		finished(4, 2, 3, 1, 1),
+		// GW5: MID host the weak side; GW6: away at the strong side and at
+		// home to the weak side; GW7 is a blank.
+		{ID: 5, Event: intPtr(5), TeamH: 3, TeamA: 2},
+		{ID: 6, Event: intPtr(6), TeamH: 1, TeamA: 3},
+		{ID: 7, Event: intPtr(6), TeamH: 3, TeamA: 2},
+		{ID: 8, Event: intPtr(7), TeamH: 1, TeamA: 2},
+		{ID: 9, TeamH: 2, TeamA: 1},
+	}
+	return b, fixtures
+}
+
+func TestSchedule(t *testing.T) {
+	_, fixtures := testData()
+	s := NewSchedule(fixtures)
+	if s.Played(3) != 4 || s.Played(1) != 2 {
+		t.Fatalf("unexpected played counts %d, %d", s.Played(3), s.Played(1))
+	}
+	if got := s.GoalsPerTeam(); math.Abs(got-9.0/8) > 1e-9 {
+		t.Fatalf("expected 9 goals over 8 team-fixtures, got %.3f", got)
+	}
+	if s.Count(3, 6) != 2 || s.Count(3, 7) != 0 {
+		t.Fatal("expected a double in GW6 and a blank in GW7")
+	}
+	if m := s.Matches(3, 5)[0]; !m.Home || m.Opponent != 2 {
+		t.Fatalf("unexpected match %+v", m)
+	}
+}
+
+func TestModel(t *testing.T) {
+	b, fixtures := testData()
+	m := NewModel(b, NewSchedule(fixtures), 5)
+
+	fwd := m.Project(10, 5)
+	sum := fwd.Appearance + fwd.Goals + fwd.Assists + fwd.CleanSheets + fwd.Conceded + fwd.Saves + fwd.Bonus + fwd.Cards
+	if math.Abs(sum-fwd.Points) > 1e-9 || fwd.Points <= 0 {
+		t.Fatalf("components %.3f do not add up to points %.3f", sum, fwd.Points)
+	}
+	if fwd.Minutes != 90 || fwd.CleanSheets != 0 || fwd.Conceded != 0 {
+		t.Fatalf("unexpected forward estimate %+v", fwd)
+	}
+
+	double := m.Project(10, 6)
+	if double.Fixtures != 2 || double.Points <= fwd.Points {
+		t.Fatalf("expected a double gameweek to beat a single, got %+v", double)
+	}
+	if blank := m.Project(10, 7); blank.Points != 0 || blank.Fixtures != 0 {
+		t.Fatalf("expected nothing in a blank gameweek, got %+v", blank)
+	}
+
+	// The same defender at home to the weak side and away at the strong side.
+	home := m.fixture(&b.Elements[1], Match{Gameweek: 6, Opponent: 2, Home: true})
+	away := m.fixture(&b.Elements[1], Match{Gameweek: 6, Opponent: 1})
+	if home.CleanSheets <= away.CleanSheets || home.Goals <= away.Goals || home.Conceded <= away.Conceded {
+		t.Fatalf("expected an easier fixture to project better: home %+v away %+v", home, away)
+	}
+
+	if sub := m.Project(12, 5); sub.Minutes >= 30 || sub.Appearance >= 1 {
+		t.Fatalf("expected a bench player to project few minutes, got %+v", sub)
+	}
+	if gk := m.Project(13, 5); gk.Points != 0 {
+		t.Fatalf("expected an injured keeper to score nothing next gameweek, got %+v", gk)
+	}
+	if gk := m.Project(13, 6); gk.Saves <= 0 {
+		t.Fatalf("expected saves for a fit keeper later on, got %+v", gk)
+	}
+	if m.Project(99, 5).Points != 0 {
+		t.Fatal("expected unknown players to project zero")
+	}
+}
+
+func TestModelBeforeFirstFixture(t *testing.T) {
+	b, fixtures := testData()
+	for i := range b.Elements {
+		el := &b.Elements[i]
+		el.Minutes, el.Starts, el.ExpectedGoals, el.ExpectedAssists, el.Bonus, el.Saves = 0, 0, "", "", 0, 0
+	}
+	b.Elements[0].EPNext = "4.5"
+	b.Elements[2].EPNext = "0.5"
+	m := NewModel(b, NewSchedule(fixtures[4:]), 5)
+
+	starter, fringe := m.Project(10, 5), m.Project(12, 5)
+	if starter.Minutes != startMinutes || starter.Goals <= 0 || starter.Bonus <= 0 {
+		t.Fatalf("expected a regular starter from ep_next and position priors, got %+v", starter)
+	}
+	if fringe.Points <= 0 || fringe.Points >= starter.Points {
+		t.Fatalf("expected a fringe player to project less than a starter, got %+v and %+v", fringe, starter)
+	}
+	if gk := m.Project(13, 5); gk.Points != 0 {
+		t.Fatalf("expected an injured keeper to score nothing, got %+v", gk)
+	}
+}
+
+func TestRate(t *testing.T) {
+	b, fixtures := testData()
+	r := NewRate(b, NewSchedule(fixtures), 5, func(el *fpl.Element) float64 { return 3 })
+	if got := r.Project(10, 6).Points; got != 6 {
+		t.Fatalf("expected 3 points per fixture in a double, got %.1f", got)
+	}
+	if got := r.Project(13, 5).Points; got != 0 {
+		t.Fatalf("expected availability to apply, got %.1f", got)
+	}
+	if got := Total(r, 10, []int{5, 6, 7}); got != 9 {
+		t.Fatalf("expected 9 points over three gameweeks, got %.1f", got)
+	}
+}
+
+func TestPairsConceded(t *testing.T) {
+	if pairsConceded(0) != 0 {
+		t.Fatal("expected no goals to cost nothing")
+	}
+	// P(2)+P(3) + 2(P(4)+P(5)) + 3(P(6)+P(7)) + ... for a Poisson mean of 1.
+	if got := pairsConceded(1); math.Abs(got-0.2838) > 1e-3 {
+		t.Fatalf("unexpected pairs conceded %.4f", got)
+	}
+}
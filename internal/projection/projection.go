@@ -0,0 +1,128 @@
+// Package projection estimates FPL points for upcoming gameweeks.
+//
+// A Projection maps a player and gameweek to an Estimate. Model builds one
+// from season rates, minutes, opponent strength and the schedule; Rate scales
+// a single per-fixture number such as form. Commands that search for squads
+// or captains score players through the same interface.
+package projection
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+// Projection estimates a player's points in a gameweek. Unknown players and
+// blank gameweeks yield a zero Estimate.
+type Projection interface {
+	Project(playerID, gw int) Estimate
+}
+
+// Estimate is a player's expected points in one gameweek, summed over their
+// fixtures. The components are expected points from each scoring category;
+// projections that do not model them leave them zero.
+type Estimate struct {
+	PlayerID int
+	Gameweek int
+	Fixtures int
+	// Minutes is the expected number of minutes played.
+	Minutes float64
+
+	Appearance  float64
+	Goals       float64
+	Assists     float64
+	CleanSheets float64
+	Conceded    float64
+	Saves       float64
+	Bonus       float64
+	Cards       float64
+
+	Points float64
+}
+
+// Add accumulates o into e.
+func (e *Estimate) Add(o Estimate) {
+	e.Fixtures += o.Fixtures
+	e.Minutes += o.Minutes
+	e.Appearance += o.Appearance
+	e.Goals += o.Goals
+	e.Assists += o.Assists
+	e.CleanSheets += o.CleanSheets
+	e.Conceded += o.Conceded
+	e.Saves += o.Saves
+	e.Bonus += o.Bonus
+	e.Cards += o.Cards
+	e.Points += o.Points
+}
+
+// Total sums a player's projected points over gameweeks.
+func Total(p Projection, playerID int, gameweeks []int) float64 {
+	total := 0.0
+	for _, gw := range gameweeks {
+		total += p.Project(playerID, gw).Points
+	}
+	return total
+}
+
+// Availability is the chance el is available in gw when next is the next
+// gameweek: their flagged chance of playing for the next gameweek, or for
+// the current one (next-1) while it is being played, and zero throughout for
+// players who have left the club or the squad.
+func Availability(el *fpl.Element, gw, next int) float64 {
+	if el.Status == "u" || el.Status == "n" {
+		return 0
+	}
+	if gw == next && el.ChanceOfPlayingNextRound != nil {
+		return float64(*el.ChanceOfPlayingNextRound) / 100
+	}
+	if gw == next-1 && el.ChanceOfPlayingThisRound != nil {
+		return float64(*el.ChanceOfPlayingThisRound) / 100
+	}
+	return 1
+}
+
+// Rate projects a fixed number of points per fixture, scaled by
+// availability.
+type Rate struct {
+	schedule *Schedule
+	next     int
+	elements map[int]*fpl.Element
+	rate     func(*fpl.Element) float64
+}
+
+// NewRate returns a Rate projecting rate(el) points per fixture.
+func NewRate(b *fpl.BootstrapStatic, s *Schedule, next int, rate func(*fpl.Element) float64) *Rate {
+	return &Rate{schedule: s, next: next, elements: indexElements(b), rate: rate}
+}
+
+// Project implements Projection.
+func (r *Rate) Project(playerID, gw int) Estimate {
+	est := Estimate{PlayerID: playerID, Gameweek: gw}
+	el := r.elements[playerID]
+	if el == nil {
+		return est
+	}
+	est.Fixtures = r.schedule.Count(el.Team, gw)
+	est.Points = r.rate(el) * float64(est.Fixtures) * Availability(el, gw, r.next)
+	return est
+}
+
+func indexElements(b *fpl.BootstrapStatic) map[int]*fpl.Element {
+	elements := make(map[int]*fpl.Element, len(b.Elements))
+	for i := range b.Elements {
+		elements[b.Elements[i].ID] = &b.Elements[i]
+	}
+	return elements
+}
+
+// stat reads one of the API's decimal strings, treating blanks and junk as
+// zero.
+func stat(value string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,89 @@
+package projection
+
+import (
+	"time"
+
+	"github.com/lpoulter1/fpl-cli/internal/fpl"
+)
+
+// defaultGoalsPerTeam stands in for the league scoring rate before any
+// fixture has finished.
+const defaultGoalsPerTeam = 1.4
+
+// Match is a fixture from one team's point of view.
+type Match struct {
+	FixtureID  int
+	Gameweek   int
+	Opponent   int
+	Home       bool
+	Difficulty int
+	Kickoff    *time.Time
+}
+
+// Schedule indexes the season's fixtures by team and gameweek.
+type Schedule struct {
+	matches map[int]map[int][]Match
+	played  map[int]int
+	goals   int
+	results int
+}
+
+// NewSchedule builds a Schedule from /fixtures/ data. Unscheduled fixtures
+// are left out.
+func NewSchedule(fixtures []fpl.Fixture) *Schedule {
+	s := &Schedule{
+		matches: make(map[int]map[int][]Match),
+		played:  make(map[int]int),
+	}
+	for _, f := range fixtures {
+		if f.Finished {
+			s.played[f.TeamH]++
+			s.played[f.TeamA]++
+			if f.TeamHScore != nil && f.TeamAScore != nil {
+				s.goals += *f.TeamHScore + *f.TeamAScore
+				s.results++
+			}
+		}
+		if f.Event == nil {
+			continue
+		}
+		gw := *f.Event
+		s.add(f.TeamH, Match{FixtureID: f.ID, Gameweek: gw, Opponent: f.TeamA, Home: true, Difficulty: f.TeamHDifficulty, Kickoff: f.KickoffTime})
+		s.add(f.TeamA, Match{FixtureID: f.ID, Gameweek: gw, Opponent: f.TeamH, Home: false, Difficulty: f.TeamADifficulty, Kickoff: f.KickoffTime})
+	}
+	return s
+}
+
+func (s *Schedule) add(team int, m Match) {
+	byGW := s.matches[team]
+	if byGW == nil {
+		byGW = make(map[int][]Match)
+		s.matches[team] = byGW
+	}
+	byGW[m.Gameweek] = append(byGW[m.Gameweek], m)
+}
+
+// Matches returns team's fixtures in gw: none in a blank gameweek, two in a
+// double.
+func (s *Schedule) Matches(team, gw int) []Match {
+	return s.matches[team][gw]
+}
+
+// Count returns how many fixtures team plays in gw.
+func (s *Schedule) Count(team, gw int) int {
+	return len(s.matches[team][gw])
+}
+
+// Played returns how many of team's fixtures have finished.
+func (s *Schedule) Played(team int) int {
+	return s.played[team]
+}
+
+// GoalsPerTeam is the average number of goals a team scores in a finished
+// fixture this season.
+func (s *Schedule) GoalsPerTeam() float64 {
+	if s.results == 0 {
+		return defaultGoalsPerTeam
+	}
+	return float64(s.goals) / float64(2*s.results)
+}